@@ -1,19 +1,46 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	_ "time/tzdata" // TIMEZONE lookups do not depend on zoneinfo in the Lambda runtime
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/parnurzeal/gorequest"
 	"github.com/telia-oss/aws-notify-slack/slack"
+	"github.com/telia-oss/aws-notify-slack/sns"
 )
 
+// verifier is shared between invocations so signing certificates are only fetched once
+var verifier = sns.NewVerifier(nil)
+
+var allowlist = sns.NewAllowlist(os.Getenv("ALLOWED_TOPIC_ARNS"), os.Getenv("ALLOWED_ACCOUNTS"))
+
 // Handler is our lambda handler invoked by the `lambda.Start` function call
-func Handler(snsEvent events.SNSEvent) {
+func Handler(snsEvent events.SNSEvent) error {
+	for _, record := range snsEvent.Records {
+		if os.Getenv("VERIFY_SNS_SIGNATURE") == "true" {
+			if err := verifier.Verify(record.SNS); err != nil {
+				log.Println("Rejecting SNS message", record.SNS.MessageID, err)
+				return err
+			}
+		}
+
+		if err := allowlist.Check(record.SNS); err != nil {
+			log.Println("SECURITY WARNING: rejecting SNS message", record.SNS.MessageID, "from", record.SNS.TopicArn, err)
+			return err
+		}
+	}
+
 	slackMessageAttachments := slack.CreateSlackMessageAttachment(snsEvent)
 	log.Println("slackMessageAttachments: ", slackMessageAttachments)
+	if slackMessageAttachments == "" {
+		return nil
+	}
 
 	slackHook := os.Getenv("SLACK_HOOK")
 	request := gorequest.New()
@@ -21,8 +48,18 @@ func Handler(snsEvent events.SNSEvent) {
 		Post(slackHook).
 		Send(slackMessageAttachments).
 		End()
+
+	return nil
 }
 
 func main() {
+	if os.Getenv("EC2_NAME_LOOKUP") == "true" {
+		cfg, err := config.LoadDefaultConfig(context.TODO())
+		if err != nil {
+			log.Fatal("Error loading AWS config", err)
+		}
+		slack.InstanceNames = slack.NewEC2InstanceNames(ec2.NewFromConfig(cfg))
+	}
+
 	lambda.Start(Handler)
 }
@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/Jeffail/gabs"
+)
+
+// acmCriticalDays is the number of days to expiry below which certificates
+// are rendered as danger
+const acmCriticalDays = 14
+
+func acmCertificateApproachingExpiration(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	commonName, _ := detail.Path("CommonName").Data().(string)
+	daysToExpiry, _ := detail.Path("DaysToExpiry").Data().(float64)
+
+	notification := eventBridgeNotification(message)
+
+	severity := SeverityWarning
+	if daysToExpiry < acmCriticalDays {
+		severity = SeverityCritical
+	}
+
+	notification.Title = fmt.Sprintf("Certificate for %s expires in %.0f days", commonName, daysToExpiry)
+	notification.Severity = severity
+	notification.State = "APPROACHING_EXPIRATION"
+	notification.Fields = []Field{
+		{
+			Title: "Domain",
+			Value: commonName,
+			Short: true,
+		},
+		{
+			Title: "Days to expiry",
+			Value: fmt.Sprintf("%.0f", daysToExpiry),
+			Short: true,
+		},
+		{
+			Title: "Certificate",
+			Value: notification.Resource,
+			Short: false,
+		},
+	}
+
+	return &notification
+}
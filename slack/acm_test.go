@@ -0,0 +1,39 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var acmCertificateExpiringEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"9c95e8e4-96a4-ef3f-b739-b6aa5b193afb\",\"detail-type\":\"ACM Certificate Approaching Expiration\",\"source\":\"aws.acm\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:acm:eu-west-1:123456789000:certificate/61f50cd4-45b9-4259-b049-d0a53682fa4b\"],\"detail\":{\"DaysToExpiry\":10,\"CommonName\":\"example.com\"}}")
+
+func TestCreateSlackMessageAttachmentForAcmCertificateExpiringEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(acmCertificateExpiringEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Domain",
+			Value: "example.com",
+			Short: true,
+		},
+		{
+			Title: "Days to expiry",
+			Value: "10",
+			Short: true,
+		},
+		{
+			Title: "Certificate",
+			Value: "arn:aws:acm:eu-west-1:123456789000:certificate/61f50cd4-45b9-4259-b049-d0a53682fa4b",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Certificate for example.com expires in 10 days", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
@@ -0,0 +1,79 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+func isAlertmanager(message *gabs.Container) bool {
+	return message.Exists("alerts") && message.Exists("groupLabels") && message.Exists("status")
+}
+
+// alertmanager parses a Prometheus Alertmanager webhook payload in the same
+// style as CloudWatch alarms
+func alertmanager(message *gabs.Container) *Notification {
+	status, _ := message.Path("status").Data().(string)
+	status = strings.ToUpper(status)
+	groupKey, _ := message.Path("groupKey").Data().(string)
+
+	alertName, _ := message.Path("groupLabels.alertname").Data().(string)
+	if alertName == "" {
+		alertName, _ = message.Path("commonLabels.alertname").Data().(string)
+	}
+
+	alerts, _ := message.Path("alerts").Children()
+
+	var fields []Field
+	for _, alert := range alerts {
+		name, _ := alert.Path("labels.alertname").Data().(string)
+		alertStatus, _ := alert.Path("status").Data().(string)
+		summary, _ := alert.Path("annotations.summary").Data().(string)
+		description, _ := alert.Path("annotations.description").Data().(string)
+
+		fields = append(fields,
+			Field{
+				Title: "Alert",
+				Value: name,
+				Short: true,
+			},
+			Field{
+				Title: "Status",
+				Value: strings.ToUpper(alertStatus),
+				Short: true,
+			},
+		)
+
+		if summary != "" {
+			fields = append(fields, Field{
+				Title: "Summary",
+				Value: summary,
+				Short: false,
+			})
+		}
+
+		if description != "" {
+			fields = append(fields, Field{
+				Title: "Description",
+				Value: description,
+				Short: false,
+			})
+		}
+	}
+
+	title := fmt.Sprintf("%s: %s", status, alertName)
+	if len(alerts) > 1 {
+		title = fmt.Sprintf("%s: %s (%d alerts)", status, alertName, len(alerts))
+	}
+
+	return &Notification{
+		Title:    title,
+		Severity: mapSeverity(status),
+		State:    status,
+		Source:   "alertmanager",
+		Resource: alertName,
+		Fields:   fields,
+		DedupKey: groupKey,
+	}
+}
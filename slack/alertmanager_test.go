@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var firingAlertmanagerEvent = testSNSEvent("{\"receiver\":\"slack\",\"status\":\"firing\",\"alerts\":[{\"status\":\"firing\",\"labels\":{\"alertname\":\"HighLatency\",\"instance\":\"api-1\",\"severity\":\"critical\"},\"annotations\":{\"summary\":\"High request latency on api-1\",\"description\":\"p99 latency is above 2s for 10 minutes\"},\"startsAt\":\"2022-05-03T07:29:20Z\",\"endsAt\":\"0001-01-01T00:00:00Z\",\"generatorURL\":\"http://prometheus/graph\",\"fingerprint\":\"1234\"},{\"status\":\"firing\",\"labels\":{\"alertname\":\"HighLatency\",\"instance\":\"api-2\",\"severity\":\"critical\"},\"annotations\":{\"summary\":\"High request latency on api-2\"},\"startsAt\":\"2022-05-03T07:29:20Z\",\"endsAt\":\"0001-01-01T00:00:00Z\",\"generatorURL\":\"http://prometheus/graph\",\"fingerprint\":\"5678\"}],\"groupLabels\":{\"alertname\":\"HighLatency\"},\"commonLabels\":{\"alertname\":\"HighLatency\",\"severity\":\"critical\"},\"commonAnnotations\":{},\"externalURL\":\"http://alertmanager\",\"version\":\"4\",\"groupKey\":\"{}:{alertname=\\\"HighLatency\\\"}\"}")
+
+var resolvedAlertmanagerEvent = testSNSEvent("{\"receiver\":\"slack\",\"status\":\"resolved\",\"alerts\":[{\"status\":\"resolved\",\"labels\":{\"alertname\":\"HighLatency\",\"instance\":\"api-1\"},\"annotations\":{\"summary\":\"High request latency on api-1\"},\"startsAt\":\"2022-05-03T07:29:20Z\",\"endsAt\":\"2022-05-03T07:49:20Z\"}],\"groupLabels\":{\"alertname\":\"HighLatency\"},\"commonLabels\":{\"alertname\":\"HighLatency\"},\"commonAnnotations\":{},\"externalURL\":\"http://alertmanager\",\"version\":\"4\"}")
+
+func TestCreateSlackMessageAttachmentForFiringAlertmanagerEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(firingAlertmanagerEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Alert",
+			Value: "HighLatency",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FIRING",
+			Short: true,
+		},
+		{
+			Title: "Summary",
+			Value: "High request latency on api-1",
+			Short: false,
+		},
+		{
+			Title: "Description",
+			Value: "p99 latency is above 2s for 10 minutes",
+			Short: false,
+		},
+		{
+			Title: "Alert",
+			Value: "HighLatency",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FIRING",
+			Short: true,
+		},
+		{
+			Title: "Summary",
+			Value: "High request latency on api-2",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "FIRING: HighLatency (2 alerts)", msg.Pretext)
+	assert.Equal(t, "AWS-bot", msg.Username)
+	assert.Equal(t, ":loudspeaker:", msg.Icon)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForResolvedAlertmanagerEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(resolvedAlertmanagerEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "RESOLVED: HighLatency", msg.Pretext)
+}
@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/Jeffail/gabs"
+)
+
+func configComplianceSeverity(complianceType string) Severity {
+	switch complianceType {
+	case "NON_COMPLIANT":
+		return SeverityCritical
+	case "INSUFFICIENT_DATA":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+func configRulesComplianceChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	ruleName, _ := detail.Path("configRuleName").Data().(string)
+	resourceType, _ := detail.Path("resourceType").Data().(string)
+	resourceID, _ := detail.Path("resourceId").Data().(string)
+	newCompliance, _ := detail.Path("newEvaluationResult.complianceType").Data().(string)
+	oldCompliance, _ := detail.Path("oldEvaluationResult.complianceType").Data().(string)
+	annotation, _ := detail.Path("newEvaluationResult.annotation").Data().(string)
+
+	if oldCompliance == "" {
+		oldCompliance = "NONE"
+	}
+
+	fields := []Field{
+		{
+			Title: "Rule",
+			Value: ruleName,
+			Short: true,
+		},
+		{
+			Title: "Resource type",
+			Value: resourceType,
+			Short: true,
+		},
+		{
+			Title: "Resource ID",
+			Value: resourceID,
+			Short: true,
+		},
+		{
+			Title: "Compliance",
+			Value: fmt.Sprintf("%s -> %s", oldCompliance, newCompliance),
+			Short: true,
+		},
+	}
+
+	if annotation != "" {
+		fields = append(fields, Field{
+			Title: "Annotation",
+			Value: annotation,
+			Short: false,
+		})
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("%s %s is %s for rule %s", resourceType, resourceID, newCompliance, ruleName)
+	notification.Severity = configComplianceSeverity(newCompliance)
+	notification.State = newCompliance
+	notification.Resource = resourceID
+	notification.Fields = fields
+
+	return &notification
+}
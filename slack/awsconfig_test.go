@@ -0,0 +1,49 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var nonCompliantConfigRuleEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"0ef0d3d4-4a8f-7e56-c5b1-3b8d6e8a2f31\",\"detail-type\":\"Config Rules Compliance Change\",\"source\":\"aws.config\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"resourceId\":\"public-bucket\",\"awsRegion\":\"eu-west-1\",\"awsAccountId\":\"123456789000\",\"configRuleName\":\"s3-bucket-public-read-prohibited\",\"recordVersion\":\"1.0\",\"configRuleARN\":\"arn:aws:config:eu-west-1:123456789000:config-rule/config-rule-abc\",\"messageType\":\"ComplianceChangeNotification\",\"newEvaluationResult\":{\"evaluationResultIdentifier\":{\"evaluationResultQualifier\":{\"configRuleName\":\"s3-bucket-public-read-prohibited\",\"resourceType\":\"AWS::S3::Bucket\",\"resourceId\":\"public-bucket\"},\"orderingTimestamp\":\"2022-05-03T07:29:10.000Z\"},\"complianceType\":\"NON_COMPLIANT\",\"resultRecordedTime\":\"2022-05-03T07:29:19.000Z\",\"configRuleInvokedTime\":\"2022-05-03T07:29:15.000Z\",\"annotation\":\"Bucket policy allows public read\"},\"oldEvaluationResult\":{\"complianceType\":\"COMPLIANT\"},\"notificationCreationTime\":\"2022-05-03T07:29:20.000Z\",\"resourceType\":\"AWS::S3::Bucket\"}}")
+
+func TestCreateSlackMessageAttachmentForNonCompliantConfigRuleEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(nonCompliantConfigRuleEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Rule",
+			Value: "s3-bucket-public-read-prohibited",
+			Short: true,
+		},
+		{
+			Title: "Resource type",
+			Value: "AWS::S3::Bucket",
+			Short: true,
+		},
+		{
+			Title: "Resource ID",
+			Value: "public-bucket",
+			Short: true,
+		},
+		{
+			Title: "Compliance",
+			Value: "COMPLIANT -> NON_COMPLIANT",
+			Short: true,
+		},
+		{
+			Title: "Annotation",
+			Value: "Bucket policy allows public read",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "AWS::S3::Bucket public-bucket is NON_COMPLIANT for rule s3-bucket-public-read-prohibited", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
@@ -0,0 +1,211 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+func backupSeverity(state string) Severity {
+	switch state {
+	case "FAILED", "EXPIRED":
+		return SeverityCritical
+	case "ABORTED", "PARTIAL":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// formatBytes renders a byte count with binary units, e.g. `1.5 GiB`
+func formatBytes(bytes float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+	unit := 0
+	for bytes >= 1024 && unit < len(units)-1 {
+		bytes /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", bytes, units[unit])
+	}
+
+	return fmt.Sprintf("%.1f %s", bytes, units[unit])
+}
+
+type backupJob struct {
+	kind          string
+	jobID         string
+	resourceArn   string
+	resourceType  string
+	vault         string
+	state         string
+	statusMessage string
+	size          string
+}
+
+func (j backupJob) notification(notification Notification) *Notification {
+	fields := []Field{
+		{
+			Title: "Resource",
+			Value: j.resourceArn,
+			Short: false,
+		},
+	}
+
+	if j.resourceType != "" {
+		fields = append(fields, Field{
+			Title: "Resource type",
+			Value: j.resourceType,
+			Short: true,
+		})
+	}
+
+	if j.vault != "" {
+		fields = append(fields, Field{
+			Title: "Vault",
+			Value: j.vault,
+			Short: true,
+		})
+	}
+
+	fields = append(fields,
+		Field{
+			Title: "Job ID",
+			Value: j.jobID,
+			Short: true,
+		},
+		Field{
+			Title: "State",
+			Value: j.state,
+			Short: true,
+		},
+	)
+
+	if j.size != "" {
+		fields = append(fields, Field{
+			Title: "Backup size",
+			Value: j.size,
+			Short: true,
+		})
+	}
+
+	if j.statusMessage != "" {
+		fields = append(fields, Field{
+			Title: "Status message",
+			Value: j.statusMessage,
+			Short: false,
+		})
+	}
+
+	resource := j.resourceArn
+	if resource == "" {
+		resource = j.jobID
+	}
+
+	notification.Title = fmt.Sprintf("%s job %s for %s", j.kind, j.state, resource)
+	notification.Severity = backupSeverity(j.state)
+	notification.State = j.state
+	notification.Resource = j.resourceArn
+	notification.Fields = fields
+
+	return &notification
+}
+
+// backupJobStateChange parses backup, restore and copy job state changes
+func backupJobStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+	detailType, _ := message.Path("detail-type").Data().(string)
+
+	job := backupJob{kind: strings.Fields(detailType)[0]}
+	job.resourceType, _ = detail.Path("resourceType").Data().(string)
+	job.statusMessage, _ = detail.Path("statusMessage").Data().(string)
+
+	job.state, _ = detail.Path("state").Data().(string)
+	if job.state == "" {
+		job.state, _ = detail.Path("status").Data().(string)
+	}
+
+	switch job.kind {
+	case "Restore":
+		job.jobID, _ = detail.Path("restoreJobId").Data().(string)
+		job.resourceArn, _ = detail.Path("createdResourceArn").Data().(string)
+	case "Copy":
+		job.jobID, _ = detail.Path("copyJobId").Data().(string)
+		job.resourceArn, _ = detail.Path("resourceArn").Data().(string)
+		job.vault, _ = detail.Path("destinationBackupVaultArn").Data().(string)
+	default:
+		job.jobID, _ = detail.Path("backupJobId").Data().(string)
+		job.resourceArn, _ = detail.Path("resourceArn").Data().(string)
+		job.vault, _ = detail.Path("backupVaultName").Data().(string)
+	}
+
+	switch size := detail.Path("backupSizeInBytes").Data().(type) {
+	case float64:
+		job.size = formatBytes(size)
+	case string:
+		if bytes, err := strconv.ParseFloat(size, 64); err == nil {
+			job.size = formatBytes(bytes)
+		}
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.DedupKey = job.jobID + "/" + job.state
+
+	return job.notification(notification)
+}
+
+var backupVaultField = regexp.MustCompile(`(Resource ARN|BackupJob ID|RestoreJob ID|CopyJob ID|Recovery point ARN)\s*:\s*(\S+?)\.?(\s|$)`)
+
+// backupVaultStates maps the phrases of vault notifications to job states
+var backupVaultStates = []struct {
+	phrase string
+	state  string
+}{
+	{"failed", "FAILED"},
+	{"expired", "EXPIRED"},
+	{"stopped", "ABORTED"},
+	{"aborted", "ABORTED"},
+	{"completed", "COMPLETED"},
+	{"started", "RUNNING"},
+}
+
+var backupVaultPrefix = regexp.MustCompile(`^An AWS Backup (restore |copy )?job`)
+
+func isBackupVaultText(message string) bool {
+	return backupVaultPrefix.MatchString(message)
+}
+
+// backupVaultText parses the plain text backup vault notifications, e.g.
+// `An AWS Backup job failed. Resource ARN : arn:... BackupJob ID : ...`, and
+// their restore and copy job counterparts
+func backupVaultText(message string) *Notification {
+	job := backupJob{kind: "Backup"}
+
+	sentence := strings.ToLower(strings.SplitN(message, ".", 2)[0])
+	for _, state := range backupVaultStates {
+		if strings.Contains(sentence, state.phrase) {
+			job.state = state.state
+			break
+		}
+	}
+
+	for _, match := range backupVaultField.FindAllStringSubmatch(message, -1) {
+		switch match[1] {
+		case "Resource ARN":
+			job.resourceArn = match[2]
+		case "BackupJob ID", "RestoreJob ID", "CopyJob ID":
+			job.kind = strings.TrimSuffix(match[1], "Job ID")
+			job.jobID = match[2]
+		}
+	}
+
+	return job.notification(Notification{
+		Source:   "aws.backup",
+		DedupKey: job.jobID + "/" + job.state,
+	})
+}
@@ -0,0 +1,123 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedBackupJobEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"dafd1cfc-5c6e-6d2d-0e56-3e6fe8a0e19c\",\"detail-type\":\"Backup Job State Change\",\"source\":\"aws.backup\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ec2:eu-west-1:123456789000:volume/vol-0123456789abcdef0\"],\"detail\":{\"backupJobId\":\"1b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6\",\"backupSizeInBytes\":\"1610612736\",\"backupVaultArn\":\"arn:aws:backup:eu-west-1:123456789000:backup-vault:Default\",\"backupVaultName\":\"Default\",\"bytesTransferred\":\"0\",\"creationDate\":\"2022-05-03T07:00:00.000Z\",\"iamRoleArn\":\"arn:aws:iam::123456789000:role/service-role/AWSBackupDefaultServiceRole\",\"resourceArn\":\"arn:aws:ec2:eu-west-1:123456789000:volume/vol-0123456789abcdef0\",\"resourceType\":\"EBS\",\"state\":\"FAILED\",\"statusMessage\":\"Insufficient privileges to perform this action.\",\"percentDone\":0}}")
+
+var completedRestoreJobEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"eafd1cfc-5c6e-6d2d-0e56-3e6fe8a0e19c\",\"detail-type\":\"Restore Job State Change\",\"source\":\"aws.backup\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"restoreJobId\":\"2b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6\",\"backupSizeInBytes\":512,\"creationDate\":\"2022-05-03T07:00:00.000Z\",\"percentDone\":100,\"recoveryPointArn\":\"arn:aws:ec2:eu-west-1::snapshot/snap-0123456789abcdef0\",\"resourceType\":\"EBS\",\"status\":\"COMPLETED\",\"createdResourceArn\":\"arn:aws:ec2:eu-west-1:123456789000:volume/vol-0fedcba9876543210\"}}")
+
+var expiredBackupVaultEvent = testSNSEvent("An AWS Backup job expired. Resource ARN : arn:aws:rds:eu-west-1:123456789000:db:orders. BackupJob ID : 3b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6")
+
+var failedRestoreVaultEvent = testSNSEvent("An AWS Backup restore job failed. Resource ARN : arn:aws:ec2:eu-west-1:123456789000:volume/vol-0123456789abcdef0. RestoreJob ID : 4b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6")
+
+var completedCopyVaultEvent = testSNSEvent("An AWS Backup copy job was completed successfully. Resource ARN : arn:aws:rds:eu-west-1:123456789000:db:orders. CopyJob ID : 5b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6")
+
+func TestCreateSlackMessageAttachmentForFailedBackupJobEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedBackupJobEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Resource",
+			Value: "arn:aws:ec2:eu-west-1:123456789000:volume/vol-0123456789abcdef0",
+			Short: false,
+		},
+		{
+			Title: "Resource type",
+			Value: "EBS",
+			Short: true,
+		},
+		{
+			Title: "Vault",
+			Value: "Default",
+			Short: true,
+		},
+		{
+			Title: "Job ID",
+			Value: "1b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6",
+			Short: true,
+		},
+		{
+			Title: "State",
+			Value: "FAILED",
+			Short: true,
+		},
+		{
+			Title: "Backup size",
+			Value: "1.5 GiB",
+			Short: true,
+		},
+		{
+			Title: "Status message",
+			Value: "Insufficient privileges to perform this action.",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Backup job FAILED for arn:aws:ec2:eu-west-1:123456789000:volume/vol-0123456789abcdef0", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForCompletedRestoreJobEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(completedRestoreJobEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Restore job COMPLETED for arn:aws:ec2:eu-west-1:123456789000:volume/vol-0fedcba9876543210", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Backup size", Value: "512 B", Short: true}, msg.Fields[4])
+}
+
+func TestCreateSlackMessageAttachmentForExpiredBackupVaultEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(expiredBackupVaultEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Resource",
+			Value: "arn:aws:rds:eu-west-1:123456789000:db:orders",
+			Short: false,
+		},
+		{
+			Title: "Job ID",
+			Value: "3b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6",
+			Short: true,
+		},
+		{
+			Title: "State",
+			Value: "EXPIRED",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Backup job EXPIRED for arn:aws:rds:eu-west-1:123456789000:db:orders", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForRestoreAndCopyVaultEvents(t *testing.T) {
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(CreateSlackMessageAttachment(failedRestoreVaultEvent)), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Restore job FAILED for arn:aws:ec2:eu-west-1:123456789000:volume/vol-0123456789abcdef0", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Job ID", Value: "4b2c3d4e-5f6a-7b8c-9d0e-f1a2b3c4d5e6", Short: true}, msg.Fields[1])
+
+	json.Unmarshal([]byte(CreateSlackMessageAttachment(completedCopyVaultEvent)), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Copy job COMPLETED for arn:aws:rds:eu-west-1:123456789000:db:orders", msg.Pretext)
+}
@@ -0,0 +1,158 @@
+package slack
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+const batchLogGroup = "/aws/batch/job"
+
+func batchSeverity(status string) Severity {
+	if status == "FAILED" {
+		return SeverityCritical
+	}
+
+	return SeverityOK
+}
+
+// batchNotify applies the BATCH_FAILED_ONLY filter: only FAILED jobs, and
+// SUCCEEDED jobs in one of the BATCH_SUCCEEDED_QUEUES, are posted
+func batchNotify(status, queue string) bool {
+	if os.Getenv("BATCH_FAILED_ONLY") != "true" {
+		return true
+	}
+
+	switch status {
+	case "FAILED":
+		return true
+	case "SUCCEEDED":
+		queueName := queue[strings.LastIndex(queue, "/")+1:]
+		for _, allowed := range commaList(os.Getenv("BATCH_SUCCEEDED_QUEUES")) {
+			if allowed == queue || allowed == queueName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// cloudWatchLogsURL links to a log stream in the CloudWatch console, which
+// expects `$25` escaped path segments
+func cloudWatchLogsURL(region, group, stream string) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.PathEscape(s), "%", "$25")
+	}
+
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#logsV2:log-groups/log-group/%s/log-events/%s",
+		region, region, escape(group), escape(stream))
+}
+
+func batchJobStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	jobName, _ := detail.Path("jobName").Data().(string)
+	jobID, _ := detail.Path("jobId").Data().(string)
+	jobQueue, _ := detail.Path("jobQueue").Data().(string)
+	jobDefinition, _ := detail.Path("jobDefinition").Data().(string)
+	status, _ := detail.Path("status").Data().(string)
+	statusReason, _ := detail.Path("statusReason").Data().(string)
+
+	if !batchNotify(status, jobQueue) {
+		log.Println("Skipping Batch job state change", jobName, status)
+		return nil
+	}
+
+	attempts, _ := detail.Path("attempts").Children()
+
+	container := detail.Path("container")
+	if len(attempts) > 0 {
+		container = attempts[len(attempts)-1].Path("container")
+	}
+	exitCode := container.Path("exitCode").Data()
+	reason, _ := container.Path("reason").Data().(string)
+	logStream, _ := container.Path("logStreamName").Data().(string)
+	if logStream == "" {
+		logStream, _ = detail.Path("container.logStreamName").Data().(string)
+	}
+
+	fields := []Field{
+		{
+			Title: "Job",
+			Value: jobName,
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+		{
+			Title: "Queue",
+			Value: jobQueue[strings.LastIndex(jobQueue, "/")+1:],
+			Short: true,
+		},
+		{
+			Title: "Definition",
+			Value: jobDefinition[strings.LastIndex(jobDefinition, "/")+1:],
+			Short: true,
+		},
+		{
+			Title: "Attempts",
+			Value: fmt.Sprintf("%d", len(attempts)),
+			Short: true,
+		},
+	}
+
+	if exitCode != nil {
+		fields = append(fields, Field{
+			Title: "Exit code",
+			Value: fmt.Sprintf("%v", exitCode),
+			Short: true,
+		})
+	}
+
+	if statusReason != "" {
+		fields = append(fields, Field{
+			Title: "Status reason",
+			Value: statusReason,
+			Short: false,
+		})
+	}
+
+	if reason != "" {
+		fields = append(fields, Field{
+			Title: "Container reason",
+			Value: reason,
+			Short: false,
+		})
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Batch job %s %s", jobName, status)
+	notification.Severity = batchSeverity(status)
+	notification.State = status
+	notification.Resource = jobID
+	notification.Fields = fields
+
+	if logStream != "" {
+		group, _ := detail.Path("container.logConfiguration.options.awslogs-group").Data().(string)
+		if group == "" {
+			group = batchLogGroup
+		}
+
+		notification.Links = []Link{
+			{
+				Title: "Logs",
+				URL:   cloudWatchLogsURL(notification.Region, group, logStream),
+			},
+		}
+	}
+
+	return &notification
+}
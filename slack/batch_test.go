@@ -0,0 +1,98 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedBatchJobEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"c8f9c4b5-76e5-d76a-f980-7011e206042b\",\"detail-type\":\"Batch Job State Change\",\"source\":\"aws.batch\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:batch:eu-west-1:123456789000:job/4c7599ae-0a82-49aa-ba5a-4727fcce14a8\"],\"detail\":{\"jobName\":\"nightly-export\",\"jobId\":\"4c7599ae-0a82-49aa-ba5a-4727fcce14a8\",\"jobQueue\":\"arn:aws:batch:eu-west-1:123456789000:job-queue/etl\",\"status\":\"FAILED\",\"statusReason\":\"Essential container in task exited\",\"attempts\":[{\"container\":{\"exitCode\":137,\"reason\":\"OutOfMemoryError: Container killed due to memory usage\",\"logStreamName\":\"export/default/0001\"},\"startedAt\":1651562400000,\"stoppedAt\":1651562700000,\"statusReason\":\"Essential container in task exited\"},{\"container\":{\"exitCode\":1,\"logStreamName\":\"export/default/0002\"},\"startedAt\":1651562760000,\"stoppedAt\":1651562960000,\"statusReason\":\"Essential container in task exited\"}],\"jobDefinition\":\"arn:aws:batch:eu-west-1:123456789000:job-definition/export:3\",\"container\":{\"image\":\"export:latest\",\"exitCode\":1,\"logStreamName\":\"export/default/0002\"}}}")
+
+var succeededBatchJobEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"d8f9c4b5-76e5-d76a-f980-7011e206042b\",\"detail-type\":\"Batch Job State Change\",\"source\":\"aws.batch\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"jobName\":\"report\",\"jobId\":\"5c7599ae-0a82-49aa-ba5a-4727fcce14a8\",\"jobQueue\":\"arn:aws:batch:eu-west-1:123456789000:job-queue/reports\",\"status\":\"SUCCEEDED\",\"attempts\":[{\"container\":{\"exitCode\":0,\"logStreamName\":\"report/default/0003\"}}],\"jobDefinition\":\"arn:aws:batch:eu-west-1:123456789000:job-definition/report:1\",\"container\":{\"exitCode\":0}}}")
+
+var customLogGroupBatchJobEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"e8f9c4b5-76e5-d76a-f980-7011e206042b\",\"detail-type\":\"Batch Job State Change\",\"source\":\"aws.batch\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"jobName\":\"import\",\"jobId\":\"6c7599ae-0a82-49aa-ba5a-4727fcce14a8\",\"jobQueue\":\"arn:aws:batch:eu-west-1:123456789000:job-queue/etl\",\"status\":\"FAILED\",\"attempts\":[{\"container\":{\"exitCode\":2,\"logStreamName\":\"import/default/0004\"}}],\"jobDefinition\":\"arn:aws:batch:eu-west-1:123456789000:job-definition/import:2\",\"container\":{\"image\":\"import:latest\",\"exitCode\":2,\"logStreamName\":\"import/default/0004\",\"logConfiguration\":{\"logDriver\":\"awslogs\",\"options\":{\"awslogs-group\":\"/etl/import\"}}}}}")
+
+func TestCreateSlackMessageAttachmentForFailedBatchJobEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedBatchJobEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Job",
+			Value: "nightly-export",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FAILED",
+			Short: true,
+		},
+		{
+			Title: "Queue",
+			Value: "etl",
+			Short: true,
+		},
+		{
+			Title: "Definition",
+			Value: "export:3",
+			Short: true,
+		},
+		{
+			Title: "Attempts",
+			Value: "2",
+			Short: true,
+		},
+		{
+			Title: "Exit code",
+			Value: "1",
+			Short: true,
+		},
+		{
+			Title: "Status reason",
+			Value: "Essential container in task exited",
+			Short: false,
+		},
+		{
+			Title: "Logs",
+			Value: "<https://eu-west-1.console.aws.amazon.com/cloudwatch/home?region=eu-west-1#logsV2:log-groups/log-group/$252Faws$252Fbatch$252Fjob/log-events/export$252Fdefault$252F0002|Logs>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Batch job nightly-export FAILED", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForBatchJobFilter(t *testing.T) {
+	t.Setenv("BATCH_FAILED_ONLY", "true")
+
+	assert.NotEqual(t, "", CreateSlackMessageAttachment(failedBatchJobEvent))
+	assert.Equal(t, "", CreateSlackMessageAttachment(succeededBatchJobEvent))
+
+	t.Setenv("BATCH_SUCCEEDED_QUEUES", "etl, reports")
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(CreateSlackMessageAttachment(succeededBatchJobEvent)), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Batch job report SUCCEEDED", msg.Pretext)
+}
+
+func TestCreateSlackMessageAttachmentForCustomLogGroupBatchJobEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(customLogGroupBatchJobEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, slackAttachmentField{
+		Title: "Logs",
+		Value: "<https://eu-west-1.console.aws.amazon.com/cloudwatch/home?region=eu-west-1#logsV2:log-groups/log-group/$252Fetl$252Fimport/log-events/import$252Fdefault$252F0004|Logs>",
+		Short: true,
+	}, msg.Fields[len(msg.Fields)-1])
+}
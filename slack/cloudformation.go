@@ -0,0 +1,134 @@
+package slack
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var cloudFormationLine = regexp.MustCompile(`^([A-Za-z]+)='(.*)$`)
+
+// parseCloudFormation parses the newline separated Key='Value' text
+// CloudFormation sends to SNS. Values may span several lines.
+func parseCloudFormation(message string) (map[string]string, bool) {
+	values := map[string]string{}
+
+	var key string
+	for _, line := range strings.Split(strings.TrimRight(message, "\r\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if match := cloudFormationLine.FindStringSubmatch(line); match != nil {
+			key = match[1]
+			values[key] = match[2]
+			continue
+		}
+
+		if key != "" {
+			values[key] += "\n" + line
+		}
+	}
+
+	for key, value := range values {
+		values[key] = strings.TrimSuffix(strings.TrimRight(value, "\r\n"), "'")
+	}
+
+	_, ok := values["StackId"]
+	return values, ok && values["ResourceStatus"] != ""
+}
+
+func cloudFormationSeverity(status string) Severity {
+	switch {
+	case strings.Contains(status, "FAILED"), strings.Contains(status, "ROLLBACK"):
+		return SeverityCritical
+	case strings.HasPrefix(status, "DELETE_"):
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// isCloudFormationTerminal reports whether the event is a stack level
+// terminal or failure state such as UPDATE_ROLLBACK_COMPLETE or CREATE_FAILED
+func isCloudFormationTerminal(values map[string]string) bool {
+	status := values["ResourceStatus"]
+
+	return values["ResourceType"] == "AWS::CloudFormation::Stack" &&
+		values["LogicalResourceId"] == values["StackName"] &&
+		(strings.HasSuffix(status, "_COMPLETE") || strings.HasSuffix(status, "_FAILED"))
+}
+
+func cloudFormation(values map[string]string) *Notification {
+	stackName := values["StackName"]
+	stackID := values["StackId"]
+	logicalResourceID := values["LogicalResourceId"]
+	status := values["ResourceStatus"]
+	reason := values["ResourceStatusReason"]
+
+	if os.Getenv("CLOUDFORMATION_TERMINAL_ONLY") == "true" && !isCloudFormationTerminal(values) {
+		log.Println("Skipping CloudFormation event", stackName, logicalResourceID, status)
+		return nil
+	}
+
+	fields := []Field{
+		{
+			Title: "Stack",
+			Value: stackName,
+			Short: true,
+		},
+		{
+			Title: "Logical resource",
+			Value: logicalResourceID,
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+	}
+
+	if reason != "" {
+		fields = append(fields, Field{
+			Title: "Reason",
+			Value: reason,
+			Short: false,
+		})
+	}
+
+	title := fmt.Sprintf("Stack %s: %s", stackName, status)
+	if logicalResourceID != stackName {
+		title = fmt.Sprintf("Stack %s: %s %s", stackName, logicalResourceID, status)
+	}
+
+	// arn:aws:cloudformation:<region>:<account>:stack/<name>/<id>
+	var region, account string
+	if parts := strings.Split(stackID, ":"); len(parts) > 4 {
+		region, account = parts[3], parts[4]
+	}
+
+	notification := Notification{
+		Title:    title,
+		Severity: cloudFormationSeverity(status),
+		State:    status,
+		Source:   "aws.cloudformation",
+		Account:  account,
+		Region:   region,
+		Resource: stackID,
+		Fields:   fields,
+		DedupKey: values["EventId"],
+	}
+
+	if region != "" {
+		notification.Links = []Link{
+			{
+				Title: "Stack",
+				URL: fmt.Sprintf("https://%s.console.aws.amazon.com/cloudformation/home?region=%s#/stacks/stackinfo?stackId=%s",
+					region, region, url.QueryEscape(stackID)),
+			},
+		}
+	}
+
+	return &notification
+}
@@ -0,0 +1,85 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rollbackCloudFormationStackEvent = testSNSEvent("StackId='arn:aws:cloudformation:eu-west-1:123456789000:stack/service/8f1d2b10-cab7-11ec-9d64-0242ac120002'\nTimestamp='2022-05-03T07:29:20.123Z'\nEventId='9a1b2c3d-cab7-11ec-9d64-0242ac120002'\nLogicalResourceId='service'\nNamespace='123456789000'\nPhysicalResourceId='arn:aws:cloudformation:eu-west-1:123456789000:stack/service/8f1d2b10-cab7-11ec-9d64-0242ac120002'\nPrincipalId='AROAEXAMPLE'\nResourceProperties='null'\nResourceStatus='UPDATE_ROLLBACK_COMPLETE'\nResourceStatusReason=''\nResourceType='AWS::CloudFormation::Stack'\nStackName='service'\nClientRequestToken='null'\n")
+
+var failedCloudFormationResourceEvent = testSNSEvent("StackId='arn:aws:cloudformation:eu-west-1:123456789000:stack/service/8f1d2b10-cab7-11ec-9d64-0242ac120002'\nTimestamp='2022-05-03T07:28:00.123Z'\nEventId='Bucket-CREATE_FAILED-2022-05-03T07:28:00.123Z'\nLogicalResourceId='Bucket'\nNamespace='123456789000'\nPhysicalResourceId=''\nPrincipalId='AROAEXAMPLE'\nResourceProperties='{\"BucketName\":\"service\",\n\"Tags\":[]}\n'\nResourceStatus='CREATE_FAILED'\nResourceStatusReason='service already exists'\nResourceType='AWS::S3::Bucket'\nStackName='service'\nClientRequestToken='null'\n")
+
+var failedCloudFormationStackEvent = testSNSEvent("StackId='arn:aws:cloudformation:eu-west-1:123456789000:stack/service/8f1d2b10-cab7-11ec-9d64-0242ac120002'\r\nTimestamp='2022-05-03T07:28:10.123Z'\r\nEventId='2b1c2d3e-cab7-11ec-9d64-0242ac120002'\r\nLogicalResourceId='service'\r\nNamespace='123456789000'\r\nPhysicalResourceId='arn:aws:cloudformation:eu-west-1:123456789000:stack/service/8f1d2b10-cab7-11ec-9d64-0242ac120002'\r\nPrincipalId='AROAEXAMPLE'\r\nResourceProperties='null'\r\nResourceStatus='CREATE_FAILED'\r\nResourceStatusReason='The following resource(s) failed to create: [Bucket]. '\r\nResourceType='AWS::CloudFormation::Stack'\r\nClientRequestToken='null'\r\nStackName='service'\r\n")
+
+func TestCreateSlackMessageAttachmentForRollbackCloudFormationStackEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(rollbackCloudFormationStackEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Stack",
+			Value: "service",
+			Short: true,
+		},
+		{
+			Title: "Logical resource",
+			Value: "service",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "UPDATE_ROLLBACK_COMPLETE",
+			Short: true,
+		},
+		{
+			Title: "Stack",
+			Value: "<https://eu-west-1.console.aws.amazon.com/cloudformation/home?region=eu-west-1#/stacks/stackinfo?stackId=arn%3Aaws%3Acloudformation%3Aeu-west-1%3A123456789000%3Astack%2Fservice%2F8f1d2b10-cab7-11ec-9d64-0242ac120002|Stack>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Stack service: UPDATE_ROLLBACK_COMPLETE", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForFailedCloudFormationResourceEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedCloudFormationResourceEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Stack service: Bucket CREATE_FAILED", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Reason", Value: "service already exists"}, msg.Fields[3])
+}
+
+func TestParseCloudFormationMultilineValue(t *testing.T) {
+	values, ok := parseCloudFormation(failedCloudFormationResourceEvent.Records[0].SNS.Message)
+
+	assert.True(t, ok)
+	assert.Equal(t, "{\"BucketName\":\"service\",\n\"Tags\":[]}\n", values["ResourceProperties"])
+	assert.Equal(t, "AWS::S3::Bucket", values["ResourceType"])
+	assert.Equal(t, "null", values["ClientRequestToken"])
+}
+
+func TestParseCloudFormationCRLF(t *testing.T) {
+	values, ok := parseCloudFormation(failedCloudFormationStackEvent.Records[0].SNS.Message)
+
+	assert.True(t, ok)
+	assert.Equal(t, "CREATE_FAILED", values["ResourceStatus"])
+	assert.Equal(t, "service", values["StackName"])
+}
+
+func TestCreateSlackMessageAttachmentForCloudFormationTerminalOnly(t *testing.T) {
+	t.Setenv("CLOUDFORMATION_TERMINAL_ONLY", "true")
+
+	assert.Equal(t, "", CreateSlackMessageAttachment(failedCloudFormationResourceEvent))
+	assert.NotEqual(t, "", CreateSlackMessageAttachment(rollbackCloudFormationStackEvent))
+	assert.NotEqual(t, "", CreateSlackMessageAttachment(failedCloudFormationStackEvent))
+}
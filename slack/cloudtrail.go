@@ -0,0 +1,241 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// iamChangeEvents are the IAM API calls that change users, policies or access keys
+var iamChangeEvents = map[string]bool{
+	"CreateUser":              true,
+	"DeleteUser":              true,
+	"CreateLoginProfile":      true,
+	"UpdateLoginProfile":      true,
+	"DeleteLoginProfile":      true,
+	"CreateAccessKey":         true,
+	"UpdateAccessKey":         true,
+	"DeleteAccessKey":         true,
+	"AddUserToGroup":          true,
+	"RemoveUserFromGroup":     true,
+	"CreatePolicy":            true,
+	"DeletePolicy":            true,
+	"CreatePolicyVersion":     true,
+	"DeletePolicyVersion":     true,
+	"SetDefaultPolicyVersion": true,
+	"AttachUserPolicy":        true,
+	"DetachUserPolicy":        true,
+	"PutUserPolicy":           true,
+	"DeleteUserPolicy":        true,
+	"AttachGroupPolicy":       true,
+	"DetachGroupPolicy":       true,
+	"PutGroupPolicy":          true,
+	"DeleteGroupPolicy":       true,
+	"AttachRolePolicy":        true,
+	"DetachRolePolicy":        true,
+	"PutRolePolicy":           true,
+	"DeleteRolePolicy":        true,
+}
+
+// cloudTrailIdentity returns a readable name for the caller of a CloudTrail event
+func cloudTrailIdentity(identity *gabs.Container) string {
+	identityType, _ := identity.Path("type").Data().(string)
+	if identityType == "Root" {
+		return "root"
+	}
+
+	for _, path := range []string{
+		"userName",
+		"sessionContext.sessionIssuer.userName",
+		"arn",
+		"principalId",
+	} {
+		if name, _ := identity.Path(path).Data().(string); name != "" {
+			return name
+		}
+	}
+
+	return identityType
+}
+
+// cloudTrailResource returns the IAM entity an API call acts on, if any
+func cloudTrailResource(parameters *gabs.Container) string {
+	for _, path := range []string{
+		"policyArn",
+		"userName",
+		"roleName",
+		"groupName",
+		"policyName",
+		"accessKeyId",
+	} {
+		if name, _ := parameters.Path(path).Data().(string); name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// cloudTrailFields are the caller details shown for every CloudTrail event
+func cloudTrailFields(detail *gabs.Container) []Field {
+	identityArn, _ := detail.Path("userIdentity.arn").Data().(string)
+	sourceIP, _ := detail.Path("sourceIPAddress").Data().(string)
+	userAgent, _ := detail.Path("userAgent").Data().(string)
+
+	return []Field{
+		{
+			Title: "User",
+			Value: cloudTrailIdentity(detail.Path("userIdentity")),
+			Short: true,
+		},
+		{
+			Title: "Source IP",
+			Value: sourceIP,
+			Short: true,
+		},
+		{
+			Title: "Identity",
+			Value: identityArn,
+			Short: false,
+		},
+		{
+			Title: "User agent",
+			Value: userAgent,
+			Short: false,
+		},
+	}
+}
+
+func cloudTrailLink(region, eventID string) []Link {
+	if eventID == "" {
+		return nil
+	}
+
+	return []Link{
+		{
+			Title: "Event",
+			URL:   fmt.Sprintf("https://%s.console.aws.amazon.com/cloudtrail/home?region=%s#/events/%s", region, region, eventID),
+		},
+	}
+}
+
+func consoleSignIn(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	identityType, _ := detail.Path("userIdentity.type").Data().(string)
+	result, _ := detail.Path("responseElements.ConsoleLogin").Data().(string)
+	mfaUsed, _ := detail.Path("additionalEventData.MFAUsed").Data().(string)
+	errorMessage, _ := detail.Path("errorMessage").Data().(string)
+	eventID, _ := detail.Path("eventID").Data().(string)
+	user := cloudTrailIdentity(detail.Path("userIdentity"))
+
+	notification := eventBridgeNotification(message)
+
+	switch {
+	case result == "Failure":
+		notification.Title = fmt.Sprintf("Failed console sign-in for %s in account %s", user, notification.Account)
+		notification.Severity = SeverityCritical
+	case identityType == "Root":
+		notification.Title = fmt.Sprintf("Root console sign-in in account %s", notification.Account)
+		notification.Severity = SeverityCritical
+	case mfaUsed == "No":
+		notification.Title = fmt.Sprintf("Console sign-in without MFA for %s in account %s", user, notification.Account)
+		notification.Severity = SeverityWarning
+	default:
+		notification.Title = fmt.Sprintf("Console sign-in for %s in account %s", user, notification.Account)
+		notification.Severity = SeverityOK
+	}
+
+	fields := append([]Field{
+		{
+			Title: "Result",
+			Value: result,
+			Short: true,
+		},
+		{
+			Title: "MFA used",
+			Value: mfaUsed,
+			Short: true,
+		},
+	}, cloudTrailFields(detail)...)
+
+	if errorMessage != "" {
+		fields = append(fields, Field{
+			Title: "Error",
+			Value: errorMessage,
+			Short: false,
+		})
+	}
+
+	notification.State = result
+	notification.Resource = user
+	notification.Fields = fields
+	notification.Links = cloudTrailLink(notification.Region, eventID)
+
+	return &notification
+}
+
+func cloudTrailAPICall(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	identityType, _ := detail.Path("userIdentity.type").Data().(string)
+	eventSource, _ := detail.Path("eventSource").Data().(string)
+	eventName, _ := detail.Path("eventName").Data().(string)
+	errorCode, _ := detail.Path("errorCode").Data().(string)
+	errorMessage, _ := detail.Path("errorMessage").Data().(string)
+	eventID, _ := detail.Path("eventID").Data().(string)
+	user := cloudTrailIdentity(detail.Path("userIdentity"))
+	resource := cloudTrailResource(detail.Path("requestParameters"))
+
+	service := strings.TrimSuffix(eventSource, ".amazonaws.com")
+	action := service + ":" + eventName
+	iamChange := service == "iam" && iamChangeEvents[eventName]
+
+	notification := eventBridgeNotification(message)
+
+	switch {
+	case identityType == "Root":
+		notification.Title = fmt.Sprintf("Root account called %s in account %s", action, notification.Account)
+		notification.Severity = SeverityCritical
+	case iamChange:
+		notification.Title = fmt.Sprintf("IAM change %s by %s in account %s", eventName, user, notification.Account)
+		notification.Severity = SeverityWarning
+	default:
+		notification.Title = fmt.Sprintf("%s called %s in account %s", user, action, notification.Account)
+		notification.Severity = SeverityOK
+	}
+
+	fields := []Field{
+		{
+			Title: "Action",
+			Value: action,
+			Short: true,
+		},
+	}
+
+	if resource != "" {
+		fields = append(fields, Field{
+			Title: "Resource",
+			Value: resource,
+			Short: true,
+		})
+	}
+
+	fields = append(fields, cloudTrailFields(detail)...)
+
+	if errorCode != "" {
+		fields = append(fields, Field{
+			Title: "Error",
+			Value: strings.TrimSpace(errorCode + " " + errorMessage),
+			Short: false,
+		})
+	}
+
+	notification.State = eventName
+	notification.Resource = resource
+	notification.Fields = fields
+	notification.Links = cloudTrailLink(notification.Region, eventID)
+
+	return &notification
+}
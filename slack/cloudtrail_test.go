@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedConsoleSignInEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"6f87d04b-9f74-4f04-a780-7acf4b0a9b38\",\"detail-type\":\"AWS Console Sign In via CloudTrail\",\"source\":\"aws.signin\",\"account\":\"123456789000\",\"time\":\"2022-05-03T08:12:45Z\",\"region\":\"us-east-1\",\"resources\":[],\"detail\":{\"eventVersion\":\"1.08\",\"userIdentity\":{\"type\":\"IAMUser\",\"principalId\":\"AIDAEXAMPLE\",\"accountId\":\"123456789000\",\"userName\":\"alice\"},\"eventTime\":\"2022-05-03T08:12:45Z\",\"eventSource\":\"signin.amazonaws.com\",\"eventName\":\"ConsoleLogin\",\"awsRegion\":\"us-east-1\",\"sourceIPAddress\":\"203.0.113.10\",\"userAgent\":\"Mozilla/5.0\",\"errorMessage\":\"Failed authentication\",\"requestParameters\":null,\"responseElements\":{\"ConsoleLogin\":\"Failure\"},\"additionalEventData\":{\"LoginTo\":\"https://console.aws.amazon.com/console/home\",\"MobileVersion\":\"No\",\"MFAUsed\":\"No\"},\"eventID\":\"a5a4e1b1-2b3c-4d5e-8f90-123456789abc\",\"eventType\":\"AwsConsoleSignIn\"}}")
+
+var rootConsoleSignInEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"7a87d04b-9f74-4f04-a780-7acf4b0a9b38\",\"detail-type\":\"AWS Console Sign In via CloudTrail\",\"source\":\"aws.signin\",\"account\":\"123456789000\",\"time\":\"2022-05-03T08:12:45Z\",\"region\":\"us-east-1\",\"resources\":[],\"detail\":{\"userIdentity\":{\"type\":\"Root\",\"principalId\":\"123456789000\",\"arn\":\"arn:aws:iam::123456789000:root\",\"accountId\":\"123456789000\"},\"eventSource\":\"signin.amazonaws.com\",\"eventName\":\"ConsoleLogin\",\"sourceIPAddress\":\"203.0.113.10\",\"userAgent\":\"Mozilla/5.0\",\"responseElements\":{\"ConsoleLogin\":\"Success\"},\"additionalEventData\":{\"MFAUsed\":\"Yes\"},\"eventID\":\"b5a4e1b1-2b3c-4d5e-8f90-123456789abc\"}}")
+
+var noMfaConsoleSignInEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"8b87d04b-9f74-4f04-a780-7acf4b0a9b38\",\"detail-type\":\"AWS Console Sign In via CloudTrail\",\"source\":\"aws.signin\",\"account\":\"123456789000\",\"time\":\"2022-05-03T08:12:45Z\",\"region\":\"us-east-1\",\"resources\":[],\"detail\":{\"userIdentity\":{\"type\":\"IAMUser\",\"arn\":\"arn:aws:iam::123456789000:user/bob\",\"userName\":\"bob\"},\"eventSource\":\"signin.amazonaws.com\",\"eventName\":\"ConsoleLogin\",\"sourceIPAddress\":\"203.0.113.11\",\"userAgent\":\"Mozilla/5.0\",\"responseElements\":{\"ConsoleLogin\":\"Success\"},\"additionalEventData\":{\"MFAUsed\":\"No\"},\"eventID\":\"c5a4e1b1-2b3c-4d5e-8f90-123456789abc\"}}")
+
+var createAccessKeyEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"9c87d04b-9f74-4f04-a780-7acf4b0a9b38\",\"detail-type\":\"AWS API Call via CloudTrail\",\"source\":\"aws.iam\",\"account\":\"123456789000\",\"time\":\"2022-05-03T08:20:00Z\",\"region\":\"us-east-1\",\"resources\":[],\"detail\":{\"eventVersion\":\"1.08\",\"userIdentity\":{\"type\":\"AssumedRole\",\"principalId\":\"AROAEXAMPLE:alice\",\"arn\":\"arn:aws:sts::123456789000:assumed-role/admin/alice\",\"accountId\":\"123456789000\",\"sessionContext\":{\"sessionIssuer\":{\"type\":\"Role\",\"arn\":\"arn:aws:iam::123456789000:role/admin\",\"userName\":\"admin\"}}},\"eventTime\":\"2022-05-03T08:20:00Z\",\"eventSource\":\"iam.amazonaws.com\",\"eventName\":\"CreateAccessKey\",\"awsRegion\":\"us-east-1\",\"sourceIPAddress\":\"203.0.113.10\",\"userAgent\":\"aws-cli/2.4.0\",\"requestParameters\":{\"userName\":\"deploy\"},\"responseElements\":{\"accessKey\":{\"accessKeyId\":\"AKIAEXAMPLE\",\"status\":\"Active\",\"userName\":\"deploy\"}},\"eventID\":\"d5a4e1b1-2b3c-4d5e-8f90-123456789abc\",\"eventType\":\"AwsApiCall\"}}")
+
+var rootAPICallEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"ad87d04b-9f74-4f04-a780-7acf4b0a9b38\",\"detail-type\":\"AWS API Call via CloudTrail\",\"source\":\"aws.s3\",\"account\":\"123456789000\",\"time\":\"2022-05-03T08:20:00Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"userIdentity\":{\"type\":\"Root\",\"arn\":\"arn:aws:iam::123456789000:root\"},\"eventSource\":\"s3.amazonaws.com\",\"eventName\":\"DeleteBucket\",\"sourceIPAddress\":\"203.0.113.10\",\"userAgent\":\"console.amazonaws.com\",\"requestParameters\":{\"bucketName\":\"logs\"},\"errorCode\":\"AccessDenied\",\"errorMessage\":\"Access Denied\",\"eventID\":\"e5a4e1b1-2b3c-4d5e-8f90-123456789abc\"}}")
+
+func TestCreateSlackMessageAttachmentForFailedConsoleSignInEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedConsoleSignInEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Result",
+			Value: "Failure",
+			Short: true,
+		},
+		{
+			Title: "MFA used",
+			Value: "No",
+			Short: true,
+		},
+		{
+			Title: "User",
+			Value: "alice",
+			Short: true,
+		},
+		{
+			Title: "Source IP",
+			Value: "203.0.113.10",
+			Short: true,
+		},
+		{
+			Title: "Identity",
+			Value: "",
+			Short: false,
+		},
+		{
+			Title: "User agent",
+			Value: "Mozilla/5.0",
+			Short: false,
+		},
+		{
+			Title: "Error",
+			Value: "Failed authentication",
+			Short: false,
+		},
+		{
+			Title: "Event",
+			Value: "<https://us-east-1.console.aws.amazon.com/cloudtrail/home?region=us-east-1#/events/a5a4e1b1-2b3c-4d5e-8f90-123456789abc|Event>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Failed console sign-in for alice in account 123456789000", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForRootConsoleSignInEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(rootConsoleSignInEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Root console sign-in in account 123456789000", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "User", Value: "root", Short: true}, msg.Fields[2])
+}
+
+func TestCreateSlackMessageAttachmentForNoMfaConsoleSignInEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(noMfaConsoleSignInEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Console sign-in without MFA for bob in account 123456789000", msg.Pretext)
+}
+
+func TestCreateSlackMessageAttachmentForCreateAccessKeyEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(createAccessKeyEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "IAM change CreateAccessKey by admin in account 123456789000", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Action", Value: "iam:CreateAccessKey", Short: true}, msg.Fields[0])
+	assert.Equal(t, slackAttachmentField{Title: "Resource", Value: "deploy", Short: true}, msg.Fields[1])
+	assert.Equal(t, slackAttachmentField{Title: "Identity", Value: "arn:aws:sts::123456789000:assumed-role/admin/alice", Short: false}, msg.Fields[4])
+}
+
+func TestCreateSlackMessageAttachmentForRootAPICallEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(rootAPICallEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Root account called s3:DeleteBucket in account 123456789000", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Error", Value: "AccessDenied Access Denied", Short: false}, msg.Fields[5])
+}
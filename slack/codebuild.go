@@ -0,0 +1,141 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+func codeBuildSeverity(status string) Severity {
+	switch status {
+	case "FAILED", "FAULT", "TIMED_OUT":
+		return SeverityCritical
+	case "STOPPED":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// codeBuildPhases renders one line per finished phase with its duration and
+// returns the first failed phase with its context message
+func codeBuildPhases(info *gabs.Container) (string, *Field) {
+	phases, _ := info.Path("phases").Children()
+
+	var lines []string
+	var failed *Field
+	for _, phase := range phases {
+		phaseType, _ := phase.Path("phase-type").Data().(string)
+		phaseStatus, _ := phase.Path("phase-status").Data().(string)
+		duration, _ := phase.Path("duration-in-seconds").Data().(float64)
+		if phaseStatus == "" {
+			continue
+		}
+
+		line := fmt.Sprintf("%s: %s (%.0fs)", phaseType, phaseStatus, duration)
+		if codeBuildSeverity(phaseStatus) == SeverityCritical {
+			line = "*" + line + "*"
+
+			if failed == nil {
+				var contexts []string
+				phaseContexts, _ := phase.Path("phase-context").Children()
+				for _, phaseContext := range phaseContexts {
+					if value, _ := phaseContext.Data().(string); value != "" {
+						contexts = append(contexts, value)
+					}
+				}
+
+				failed = &Field{
+					Title: "Failed phase " + phaseType,
+					Value: strings.Join(contexts, "\n"),
+					Short: false,
+				}
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), failed
+}
+
+// codeBuildStateChange parses build state and build phase changes
+func codeBuildStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+	info := detail.Path("additional-information")
+
+	projectName, _ := detail.Path("project-name").Data().(string)
+	buildID, _ := detail.Path("build-id").Data().(string)
+	buildNumber, _ := info.Path("build-number").Data().(float64)
+	initiator, _ := info.Path("initiator").Data().(string)
+	sourceVersion, _ := info.Path("source-version").Data().(string)
+	deepLink, _ := info.Path("logs.deep-link").Data().(string)
+
+	status, _ := detail.Path("build-status").Data().(string)
+	title := fmt.Sprintf("Build %s #%.0f %s", projectName, buildNumber, status)
+
+	completedPhase, _ := detail.Path("completed-phase").Data().(string)
+	if completedPhase != "" {
+		status, _ = detail.Path("completed-phase-status").Data().(string)
+		title = fmt.Sprintf("Build %s #%.0f phase %s %s", projectName, buildNumber, completedPhase, status)
+	}
+
+	fields := []Field{
+		{
+			Title: "Project",
+			Value: projectName,
+			Short: true,
+		},
+		{
+			Title: "Build number",
+			Value: fmt.Sprintf("%.0f", buildNumber),
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+		{
+			Title: "Initiator",
+			Value: initiator,
+			Short: true,
+		},
+		{
+			Title: "Source version",
+			Value: sourceVersion,
+			Short: true,
+		},
+	}
+
+	phases, failed := codeBuildPhases(info)
+	if phases != "" {
+		fields = append(fields, Field{
+			Title: "Phases",
+			Value: phases,
+			Short: false,
+		})
+	}
+
+	if failed != nil {
+		fields = append(fields, *failed)
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Title = title
+	notification.Severity = codeBuildSeverity(status)
+	notification.State = status
+	notification.Resource = buildID
+	notification.Fields = fields
+
+	if deepLink != "" {
+		notification.Links = []Link{
+			{
+				Title: "Build logs",
+				URL:   deepLink,
+			},
+		}
+	}
+
+	return &notification
+}
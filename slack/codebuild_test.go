@@ -0,0 +1,91 @@
+package slack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedBuildStateEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"bfdc1220-60ff-44bd-8be2-e2dbe45cd7a6\",\"detail-type\":\"CodeBuild Build State Change\",\"source\":\"aws.codebuild\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codebuild:eu-west-1:123456789000:build/service:6b7f4a2e\"],\"detail\":{\"build-status\":\"FAILED\",\"project-name\":\"service\",\"build-id\":\"arn:aws:codebuild:eu-west-1:123456789000:build/service:6b7f4a2e\",\"additional-information\":{\"build-complete\":true,\"build-number\":42,\"initiator\":\"codepipeline/release\",\"build-start-time\":\"May 3, 2022 7:27:00 AM\",\"source-version\":\"refs/heads/main\",\"logs\":{\"group-name\":\"/aws/codebuild/service\",\"stream-name\":\"6b7f4a2e\",\"deep-link\":\"https://console.aws.amazon.com/cloudwatch/home?region=eu-west-1#logEvent:group=/aws/codebuild/service;stream=6b7f4a2e\"},\"phases\":[{\"phase-context\":[],\"start-time\":\"May 3, 2022 7:27:00 AM\",\"end-time\":\"May 3, 2022 7:27:00 AM\",\"duration-in-seconds\":0,\"phase-type\":\"SUBMITTED\",\"phase-status\":\"SUCCEEDED\"},{\"phase-context\":[\": \"],\"start-time\":\"May 3, 2022 7:27:00 AM\",\"end-time\":\"May 3, 2022 7:27:35 AM\",\"duration-in-seconds\":35,\"phase-type\":\"PROVISIONING\",\"phase-status\":\"SUCCEEDED\"},{\"phase-context\":[\"COMMAND_EXECUTION_ERROR: Error while executing command: make test. Reason: exit status 2\"],\"start-time\":\"May 3, 2022 7:27:35 AM\",\"end-time\":\"May 3, 2022 7:29:10 AM\",\"duration-in-seconds\":95,\"phase-type\":\"BUILD\",\"phase-status\":\"FAILED\"},{\"start-time\":\"May 3, 2022 7:29:10 AM\",\"phase-type\":\"COMPLETED\"}]},\"current-phase\":\"COMPLETED\",\"current-phase-context\":\"[]\",\"version\":\"1\"}}")
+
+var succeededBuildPhaseEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"43ddc2bd-af76-9ca5-2dc7-b695e15adeb0\",\"detail-type\":\"CodeBuild Build Phase Change\",\"source\":\"aws.codebuild\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:27:35Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codebuild:eu-west-1:123456789000:build/service:6b7f4a2e\"],\"detail\":{\"completed-phase\":\"PROVISIONING\",\"project-name\":\"service\",\"build-id\":\"arn:aws:codebuild:eu-west-1:123456789000:build/service:6b7f4a2e\",\"completed-phase-context\":\"[: ]\",\"additional-information\":{\"build-complete\":false,\"build-number\":42,\"initiator\":\"codepipeline/release\",\"source-version\":\"refs/heads/main\",\"phases\":[{\"phase-context\":[],\"duration-in-seconds\":0,\"phase-type\":\"SUBMITTED\",\"phase-status\":\"SUCCEEDED\"},{\"phase-context\":[],\"duration-in-seconds\":35,\"phase-type\":\"PROVISIONING\",\"phase-status\":\"SUCCEEDED\"},{\"phase-type\":\"DOWNLOAD_SOURCE\"}]},\"completed-phase-status\":\"SUCCEEDED\",\"completed-phase-duration-seconds\":35,\"version\":\"1\"}}")
+
+func TestCreateSlackMessageAttachmentForFailedBuildStateEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedBuildStateEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Project",
+			Value: "service",
+			Short: true,
+		},
+		{
+			Title: "Build number",
+			Value: "42",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FAILED",
+			Short: true,
+		},
+		{
+			Title: "Initiator",
+			Value: "codepipeline/release",
+			Short: true,
+		},
+		{
+			Title: "Source version",
+			Value: "refs/heads/main",
+			Short: true,
+		},
+		{
+			Title: "Phases",
+			Value: "SUBMITTED: SUCCEEDED (0s)\nPROVISIONING: SUCCEEDED (35s)\n*BUILD: FAILED (95s)*",
+			Short: false,
+		},
+		{
+			Title: "Failed phase BUILD",
+			Value: "COMMAND_EXECUTION_ERROR: Error while executing command: make test. Reason: exit status 2",
+			Short: false,
+		},
+		{
+			Title: "Build logs",
+			Value: "<https://console.aws.amazon.com/cloudwatch/home?region=eu-west-1#logEvent:group=/aws/codebuild/service;stream=6b7f4a2e|Build logs>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Build service #42 FAILED", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForSucceededBuildPhaseEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(succeededBuildPhaseEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Build service #42 phase PROVISIONING SUCCEEDED", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Phases", Value: "SUBMITTED: SUCCEEDED (0s)\nPROVISIONING: SUCCEEDED (35s)"}, msg.Fields[5])
+}
+
+func TestCreateSlackMessageAttachmentForLargeBuildNumber(t *testing.T) {
+	event := testSNSEvent(strings.Replace(succeededBuildPhaseEvent.Records[0].SNS.Message, "\"build-number\":42", "\"build-number\":1234567", 1))
+	slackMessageAttachments := CreateSlackMessageAttachment(event)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "Build service #1234567 phase PROVISIONING SUCCEEDED", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Build number", Value: "1234567", Short: true}, msg.Fields[1])
+}
@@ -0,0 +1,163 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// codeDeployment holds what EventBridge events and legacy SNS triggers both
+// tell about a deployment
+type codeDeployment struct {
+	application     string
+	deploymentGroup string
+	deploymentID    string
+	instanceID      string
+	status          string
+	errorCode       string
+	errorMessage    string
+	rollback        string
+}
+
+func codeDeploySeverity(status string) Severity {
+	switch status {
+	case "FAILURE", "FAILED":
+		return SeverityCritical
+	case "STOP", "STOPPED":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+func (d codeDeployment) notification(notification Notification) *Notification {
+	fields := []Field{
+		{
+			Title: "Application",
+			Value: d.application,
+			Short: true,
+		},
+		{
+			Title: "Deployment group",
+			Value: d.deploymentGroup,
+			Short: true,
+		},
+		{
+			Title: "Deployment ID",
+			Value: d.deploymentID,
+			Short: true,
+		},
+	}
+
+	if d.instanceID != "" {
+		fields = append(fields, Field{
+			Title: "Instance",
+			Value: d.instanceID,
+			Short: true,
+		})
+	}
+
+	fields = append(fields, Field{
+		Title: "Status",
+		Value: d.status,
+		Short: true,
+	})
+
+	if d.errorCode != "" || d.errorMessage != "" {
+		fields = append(fields, Field{
+			Title: "Error",
+			Value: strings.TrimPrefix(d.errorCode+": "+d.errorMessage, ": "),
+			Short: false,
+		})
+	}
+
+	severity := codeDeploySeverity(d.status)
+	title := fmt.Sprintf("Deployment %s of %s/%s %s", d.deploymentID, d.application, d.deploymentGroup, d.status)
+	if d.instanceID != "" {
+		title = fmt.Sprintf("Instance %s in deployment %s of %s/%s %s", d.instanceID, d.deploymentID, d.application, d.deploymentGroup, d.status)
+	}
+
+	if d.rollback != "" {
+		title = "ROLLBACK: " + title
+		severity = SeverityCritical
+		fields = append(fields, Field{
+			Title: "Rollback",
+			Value: d.rollback,
+			Short: false,
+		})
+	}
+
+	notification.Title = title
+	notification.Severity = severity
+	notification.State = d.status
+	notification.Resource = d.deploymentID
+	notification.Fields = fields
+
+	return &notification
+}
+
+// codeDeployStateChange parses EventBridge deployment and instance state
+// changes. These carry no rollback information, only legacy SNS triggers do.
+func codeDeployStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	deployment := codeDeployment{}
+	deployment.application, _ = detail.Path("application").Data().(string)
+	deployment.deploymentGroup, _ = detail.Path("deploymentGroup").Data().(string)
+	deployment.deploymentID, _ = detail.Path("deploymentId").Data().(string)
+	deployment.instanceID, _ = detail.Path("instanceId").Data().(string)
+	deployment.status, _ = detail.Path("state").Data().(string)
+	deployment.errorCode, _ = detail.Path("errorInformation.ErrorCode").Data().(string)
+	deployment.errorMessage, _ = detail.Path("errorInformation.ErrorMessage").Data().(string)
+
+	return deployment.notification(eventBridgeNotification(message))
+}
+
+func isCodeDeployTrigger(message *gabs.Container) bool {
+	return message.Exists("eventTriggerName") && message.Exists("deploymentId")
+}
+
+// codeDeployTrigger parses the JSON sent by legacy CodeDeploy SNS triggers, in
+// which errorInformation and rollbackInformation are JSON encoded strings
+func codeDeployTrigger(message *gabs.Container) *Notification {
+	deployment := codeDeployment{}
+	deployment.application, _ = message.Path("applicationName").Data().(string)
+	deployment.deploymentGroup, _ = message.Path("deploymentGroupName").Data().(string)
+	deployment.deploymentID, _ = message.Path("deploymentId").Data().(string)
+	deployment.instanceID, _ = message.Path("instanceId").Data().(string)
+
+	deployment.status, _ = message.Path("status").Data().(string)
+	if deployment.instanceID != "" {
+		deployment.status, _ = message.Path("instanceStatus").Data().(string)
+	}
+
+	if errorInformation, err := parseEmbeddedJSON(message, "errorInformation"); err == nil {
+		deployment.errorCode, _ = errorInformation.Path("ErrorCode").Data().(string)
+		deployment.errorMessage, _ = errorInformation.Path("ErrorMessage").Data().(string)
+	}
+
+	if rollbackInformation, err := parseEmbeddedJSON(message, "rollbackInformation"); err == nil {
+		deployment.rollback, _ = rollbackInformation.Path("RollbackMessage").Data().(string)
+	}
+
+	region, _ := message.Path("region").Data().(string)
+	account, _ := message.Path("accountId").Data().(string)
+
+	return deployment.notification(Notification{
+		Source:   "aws.codedeploy",
+		Account:  account,
+		Region:   region,
+		DedupKey: deployment.deploymentID + "/" + deployment.instanceID + "/" + deployment.status,
+	})
+}
+
+// parseEmbeddedJSON parses a message field holding a JSON encoded string
+func parseEmbeddedJSON(message *gabs.Container, path string) (*gabs.Container, error) {
+	value, ok := message.Path(path).Data().(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a string", path)
+	}
+
+	return gabs.ParseJSON([]byte(value))
+}
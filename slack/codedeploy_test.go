@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedDeploymentStateEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d\",\"detail-type\":\"CodeDeploy Deployment State-change Notification\",\"source\":\"aws.codedeploy\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codedeploy:eu-west-1:123456789000:deploymentgroup:service/production\"],\"detail\":{\"instanceGroupId\":\"9fd2fbef-2157-40d8-91e7-6845af69e2d2\",\"region\":\"eu-west-1\",\"application\":\"service\",\"deploymentId\":\"d-123456789\",\"state\":\"FAILURE\",\"deploymentGroup\":\"production\"}}")
+
+var succeededInstanceStateEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6e\",\"detail-type\":\"CodeDeploy Instance State-change Notification\",\"source\":\"aws.codedeploy\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ec2:eu-west-1:123456789000:instance/i-0123456789abcdef0\"],\"detail\":{\"instanceId\":\"i-0123456789abcdef0\",\"region\":\"eu-west-1\",\"state\":\"SUCCESS\",\"application\":\"service\",\"deploymentId\":\"d-123456789\",\"instanceGroupId\":\"9fd2fbef-2157-40d8-91e7-6845af69e2d2\",\"deploymentGroup\":\"production\"}}")
+
+var rolledBackDeploymentTriggerEvent = testSNSEvent("{\"region\":\"eu-west-1\",\"accountId\":\"123456789000\",\"eventTriggerName\":\"deployments\",\"applicationName\":\"service\",\"deploymentId\":\"d-123456789\",\"deploymentGroupName\":\"production\",\"createTime\":\"Tue May 03 07:20:00 UTC 2022\",\"completeTime\":\"Tue May 03 07:29:20 UTC 2022\",\"deploymentOverview\":\"{\\\"Failed\\\":\\\"1\\\",\\\"InProgress\\\":\\\"0\\\",\\\"Pending\\\":\\\"0\\\",\\\"Skipped\\\":\\\"1\\\",\\\"Succeeded\\\":\\\"0\\\"}\",\"status\":\"FAILED\",\"errorInformation\":\"{\\\"ErrorCode\\\":\\\"HEALTH_CONSTRAINTS\\\",\\\"ErrorMessage\\\":\\\"The overall deployment failed because too many individual instances failed deployment\\\"}\",\"rollbackInformation\":\"{\\\"RollbackDeploymentId\\\":\\\"d-987654321\\\",\\\"RollbackMessage\\\":\\\"Automatic rollback triggered by deployment failure\\\"}\"}")
+
+var startedRollbackDeploymentStateEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6f\",\"detail-type\":\"CodeDeploy Deployment State-change Notification\",\"source\":\"aws.codedeploy\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:25Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codedeploy:eu-west-1:123456789000:deploymentgroup:service/production\"],\"detail\":{\"instanceGroupId\":\"9fd2fbef-2157-40d8-91e7-6845af69e2d2\",\"region\":\"eu-west-1\",\"application\":\"service\",\"deploymentId\":\"d-987654321\",\"state\":\"START\",\"deploymentGroup\":\"production\"}}")
+
+func TestCreateSlackMessageAttachmentForFailedDeploymentStateEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedDeploymentStateEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Application",
+			Value: "service",
+			Short: true,
+		},
+		{
+			Title: "Deployment group",
+			Value: "production",
+			Short: true,
+		},
+		{
+			Title: "Deployment ID",
+			Value: "d-123456789",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FAILURE",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Deployment d-123456789 of service/production FAILURE", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForSucceededInstanceStateEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(succeededInstanceStateEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Instance i-0123456789abcdef0 in deployment d-123456789 of service/production SUCCESS", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Instance", Value: "i-0123456789abcdef0", Short: true}, msg.Fields[3])
+}
+
+func TestCreateSlackMessageAttachmentForRolledBackDeploymentTriggerEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(rolledBackDeploymentTriggerEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Application",
+			Value: "service",
+			Short: true,
+		},
+		{
+			Title: "Deployment group",
+			Value: "production",
+			Short: true,
+		},
+		{
+			Title: "Deployment ID",
+			Value: "d-123456789",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FAILED",
+			Short: true,
+		},
+		{
+			Title: "Error",
+			Value: "HEALTH_CONSTRAINTS: The overall deployment failed because too many individual instances failed deployment",
+			Short: false,
+		},
+		{
+			Title: "Rollback",
+			Value: "Automatic rollback triggered by deployment failure",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "ROLLBACK: Deployment d-123456789 of service/production FAILED", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForStartedRollbackDeploymentStateEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(startedRollbackDeploymentStateEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	// EventBridge does not tell rollback deployments apart from others
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Deployment d-987654321 of service/production START", msg.Pretext)
+	for _, field := range msg.Fields {
+		assert.NotEqual(t, "Rollback", field.Title)
+	}
+}
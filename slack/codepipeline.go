@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/Jeffail/gabs"
+)
+
+func codePipelineSeverity(state string) Severity {
+	switch state {
+	case "FAILED":
+		return SeverityCritical
+	case "SUPERSEDED", "STOPPED":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// codePipelineStateChange parses pipeline, stage and action execution state
+// changes
+func codePipelineStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	pipeline, _ := detail.Path("pipeline").Data().(string)
+	executionID, _ := detail.Path("execution-id").Data().(string)
+	stage, _ := detail.Path("stage").Data().(string)
+	action, _ := detail.Path("action").Data().(string)
+	state, _ := detail.Path("state").Data().(string)
+
+	notification := eventBridgeNotification(message)
+
+	fields := []Field{
+		{
+			Title: "Pipeline",
+			Value: pipeline,
+			Short: true,
+		},
+	}
+
+	if stage != "" {
+		fields = append(fields, Field{
+			Title: "Stage",
+			Value: stage,
+			Short: true,
+		})
+	}
+
+	if action != "" {
+		fields = append(fields, Field{
+			Title: "Action",
+			Value: action,
+			Short: true,
+		})
+	}
+
+	fields = append(fields,
+		Field{
+			Title: "Execution ID",
+			Value: executionID,
+			Short: true,
+		},
+		Field{
+			Title: "State",
+			Value: state,
+			Short: true,
+		},
+	)
+
+	title := fmt.Sprintf("Pipeline %s execution %s", pipeline, state)
+	switch {
+	case action != "":
+		title = fmt.Sprintf("Action %s in stage %s of pipeline %s %s", action, stage, pipeline, state)
+	case stage != "":
+		title = fmt.Sprintf("Stage %s of pipeline %s %s", stage, pipeline, state)
+	}
+
+	notification.Title = title
+	notification.Severity = codePipelineSeverity(state)
+	notification.State = state
+	notification.Resource = pipeline
+	notification.Fields = fields
+	notification.Links = []Link{
+		{
+			Title: "Execution",
+			URL: fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codepipeline/pipelines/%s/executions/%s/timeline?region=%s",
+				notification.Region, pipeline, executionID, notification.Region),
+		},
+	}
+
+	return &notification
+}
@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedPipelineExecutionEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"01234567-0123-0123-0123-012345678901\",\"detail-type\":\"CodePipeline Pipeline Execution State Change\",\"source\":\"aws.codepipeline\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codepipeline:eu-west-1:123456789000:release\"],\"detail\":{\"pipeline\":\"release\",\"execution-id\":\"01234567-0123-0123-0123-012345678901\",\"state\":\"FAILED\",\"version\":3}}")
+
+var supersededActionExecutionEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"01234567-0123-0123-0123-012345678902\",\"detail-type\":\"CodePipeline Action Execution State Change\",\"source\":\"aws.codepipeline\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codepipeline:eu-west-1:123456789000:release\"],\"detail\":{\"pipeline\":\"release\",\"execution-id\":\"01234567-0123-0123-0123-012345678901\",\"stage\":\"Deploy\",\"action\":\"DeployService\",\"state\":\"SUPERSEDED\",\"region\":\"eu-west-1\",\"type\":{\"owner\":\"AWS\",\"category\":\"Deploy\",\"provider\":\"ECS\",\"version\":\"1\"},\"version\":3}}")
+
+var succeededStageExecutionEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"01234567-0123-0123-0123-012345678903\",\"detail-type\":\"CodePipeline Stage Execution State Change\",\"source\":\"aws.codepipeline\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:codepipeline:eu-west-1:123456789000:release\"],\"detail\":{\"pipeline\":\"release\",\"execution-id\":\"01234567-0123-0123-0123-012345678901\",\"stage\":\"Build\",\"state\":\"SUCCEEDED\",\"version\":3}}")
+
+func TestCreateSlackMessageAttachmentForFailedPipelineExecutionEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedPipelineExecutionEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Pipeline",
+			Value: "release",
+			Short: true,
+		},
+		{
+			Title: "Execution ID",
+			Value: "01234567-0123-0123-0123-012345678901",
+			Short: true,
+		},
+		{
+			Title: "State",
+			Value: "FAILED",
+			Short: true,
+		},
+		{
+			Title: "Execution",
+			Value: "<https://eu-west-1.console.aws.amazon.com/codesuite/codepipeline/pipelines/release/executions/01234567-0123-0123-0123-012345678901/timeline?region=eu-west-1|Execution>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Pipeline release execution FAILED", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForSupersededActionExecutionEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(supersededActionExecutionEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Action DeployService in stage Deploy of pipeline release SUPERSEDED", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Stage", Value: "Deploy", Short: true}, msg.Fields[1])
+	assert.Equal(t, slackAttachmentField{Title: "Action", Value: "DeployService", Short: true}, msg.Fields[2])
+}
+
+func TestCreateSlackMessageAttachmentForSucceededStageExecutionEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(succeededStageExecutionEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Stage Build of pipeline release SUCCEEDED", msg.Pretext)
+}
@@ -0,0 +1,281 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+const costExplorerURL = "https://console.aws.amazon.com/cost-management/home#/cost-explorer"
+
+const budgetsURL = "https://console.aws.amazon.com/billing/home#/budgets"
+
+// parseDollars parses amounts such as `$1,000.00` or `> $800.00`
+func parseDollars(amount string) (float64, bool) {
+	amount = strings.TrimLeft(amount, "<>= $")
+	value, err := strconv.ParseFloat(strings.ReplaceAll(amount, ",", ""), 64)
+
+	return value, err == nil
+}
+
+// formatDollars renders amounts the way AWS Budgets does, e.g. `$1,000.00`
+func formatDollars(amount float64) string {
+	digits := fmt.Sprintf("%.2f", amount)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	whole, cents := digits[:len(digits)-3], digits[len(digits)-3:]
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+
+	return sign + "$" + whole + cents
+}
+
+type budgetAlert struct {
+	account        string
+	name           string
+	budgetType     string
+	alertType      string
+	threshold      string
+	budgetedAmount string
+	amount         string
+}
+
+func (b budgetAlert) notification() *Notification {
+	severity := SeverityWarning
+	usage := ""
+
+	budgeted, okBudgeted := parseDollars(b.budgetedAmount)
+	amount, okAmount := parseDollars(b.amount)
+	if okBudgeted && okAmount && budgeted > 0 {
+		percentage := amount / budgeted * 100
+		usage = fmt.Sprintf("%.1f%% of budget", percentage)
+		if percentage > 100 {
+			usage = fmt.Sprintf("%.1f%% over budget", percentage-100)
+		}
+		if b.alertType == "ACTUAL" && percentage >= 100 {
+			severity = SeverityCritical
+		}
+	}
+
+	fields := []Field{
+		{
+			Title: "Budget",
+			Value: b.name,
+			Short: true,
+		},
+		{
+			Title: "Budget type",
+			Value: b.budgetType,
+			Short: true,
+		},
+		{
+			Title: "Budgeted amount",
+			Value: b.budgetedAmount,
+			Short: true,
+		},
+		{
+			Title: b.alertType + " amount",
+			Value: b.amount,
+			Short: true,
+		},
+		{
+			Title: "Alert threshold",
+			Value: b.threshold,
+			Short: true,
+		},
+	}
+
+	if usage != "" {
+		fields = append(fields, Field{
+			Title: "Usage",
+			Value: usage,
+			Short: true,
+		})
+	}
+
+	return &Notification{
+		Title:    fmt.Sprintf("Budget %s: %s amount %s of %s", b.name, b.alertType, b.amount, b.budgetedAmount),
+		Severity: severity,
+		State:    b.alertType,
+		Source:   "aws.budgets",
+		Account:  b.account,
+		Resource: b.name,
+		Fields:   fields,
+		Links: []Link{
+			{
+				Title: "Budgets",
+				URL:   budgetsURL,
+			},
+			{
+				Title: "Cost Explorer",
+				URL:   costExplorerURL,
+			},
+		},
+	}
+}
+
+func isBudgetText(message string) bool {
+	return strings.HasPrefix(strings.TrimSpace(message), "AWS Budget Notification")
+}
+
+// budgetText parses the plain text e-mail style notification AWS Budgets
+// sends to SNS, made of `Key: Value` lines
+func budgetText(message string) *Notification {
+	values := map[string]string{}
+	for _, line := range strings.Split(message, "\n") {
+		if i := strings.Index(line, ": "); i > 0 {
+			values[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+2:])
+		} else if strings.HasPrefix(line, "AWS Account ") {
+			values["AWS Account"] = strings.TrimSpace(strings.TrimPrefix(line, "AWS Account "))
+		}
+	}
+
+	alertType := values["Alert Type"]
+
+	return budgetAlert{
+		account:        values["AWS Account"],
+		name:           values["Budget Name"],
+		budgetType:     values["Budget Type"],
+		alertType:      alertType,
+		threshold:      values["Alert Threshold"],
+		budgetedAmount: values["Budgeted Amount"],
+		amount:         values[alertType+" Amount"],
+	}.notification()
+}
+
+func isBudgetJSON(message *gabs.Container) bool {
+	return message.Exists("budgetName") && message.Exists("budgetedAmount")
+}
+
+// budgetJSON parses the JSON variant of budget notifications
+func budgetJSON(message *gabs.Container) *Notification {
+	alert := budgetAlert{}
+	alert.account, _ = message.Path("accountId").Data().(string)
+	alert.name, _ = message.Path("budgetName").Data().(string)
+	alert.budgetType, _ = message.Path("budgetType").Data().(string)
+	alert.alertType, _ = message.Path("alertType").Data().(string)
+	switch threshold := message.Path("alertThreshold").Data().(type) {
+	case string:
+		alert.threshold = threshold
+	case float64:
+		alert.threshold = strconv.FormatFloat(threshold, 'f', -1, 64)
+	}
+	alert.budgetedAmount = formatDollars(jsonAmount(message, "budgetedAmount"))
+
+	if alert.alertType == "FORECASTED" {
+		alert.amount = formatDollars(jsonAmount(message, "forecastedAmount"))
+	} else {
+		alert.amount = formatDollars(jsonAmount(message, "actualAmount"))
+	}
+
+	return alert.notification()
+}
+
+// jsonAmount reads an amount given either as a number or a string
+func jsonAmount(message *gabs.Container, path string) float64 {
+	switch value := message.Path(path).Data().(type) {
+	case float64:
+		return value
+	case string:
+		amount, _ := parseDollars(value)
+		return amount
+	}
+
+	return 0
+}
+
+func isCostAnomaly(message *gabs.Container) bool {
+	return message.Exists("anomalyId") && message.Exists("impact")
+}
+
+// costAnomaly parses Cost Anomaly Detection alerts
+func costAnomaly(message *gabs.Container) *Notification {
+	account, _ := message.Path("accountId").Data().(string)
+	anomalyID, _ := message.Path("anomalyId").Data().(string)
+	monitorName, _ := message.Path("monitorName").Data().(string)
+	startDate, _ := message.Path("anomalyStartDate").Data().(string)
+	endDate, _ := message.Path("anomalyEndDate").Data().(string)
+	detailsLink, _ := message.Path("anomalyDetailsLink").Data().(string)
+
+	totalImpact := jsonAmount(message, "impact.totalImpact")
+	actualSpend := jsonAmount(message, "impact.totalActualSpend")
+	expectedSpend := jsonAmount(message, "impact.totalExpectedSpend")
+	impactPercentage := jsonAmount(message, "impact.totalImpactPercentage")
+
+	var causes []string
+	rootCauses, _ := message.Path("rootCauses").Children()
+	for _, rootCause := range rootCauses {
+		var parts []string
+		for _, key := range []string{"service", "linkedAccount", "region", "usageType"} {
+			if value, _ := rootCause.Path(key).Data().(string); value != "" {
+				parts = append(parts, value)
+			}
+		}
+		causes = append(causes, strings.Join(parts, " / "))
+	}
+
+	fields := []Field{
+		{
+			Title: "Monitor",
+			Value: monitorName,
+			Short: true,
+		},
+		{
+			Title: "Impact",
+			Value: fmt.Sprintf("%s (%.1f%%)", formatDollars(totalImpact), impactPercentage),
+			Short: true,
+		},
+		{
+			Title: "Actual spend",
+			Value: formatDollars(actualSpend),
+			Short: true,
+		},
+		{
+			Title: "Expected spend",
+			Value: formatDollars(expectedSpend),
+			Short: true,
+		},
+		{
+			Title: "Start date",
+			Value: startDate,
+			Short: true,
+		},
+		{
+			Title: "End date",
+			Value: endDate,
+			Short: true,
+		},
+	}
+
+	if len(causes) > 0 {
+		fields = append(fields, Field{
+			Title: "Root causes",
+			Value: strings.Join(causes, "\n"),
+			Short: false,
+		})
+	}
+
+	var links []Link
+	if detailsLink != "" {
+		links = append(links, Link{Title: "Anomaly", URL: detailsLink})
+	}
+	links = append(links, Link{Title: "Cost Explorer", URL: costExplorerURL})
+
+	return &Notification{
+		Title:    fmt.Sprintf("Cost anomaly detected by %s: %s impact", monitorName, formatDollars(totalImpact)),
+		Severity: SeverityWarning,
+		State:    "ANOMALY",
+		Source:   "aws.ce",
+		Account:  account,
+		Resource: anomalyID,
+		Fields:   fields,
+		Links:    links,
+		DedupKey: anomalyID,
+	}
+}
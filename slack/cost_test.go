@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var budgetTextEvent = testSNSEvent("AWS Budget Notification May 03, 2022\nAWS Account 123456789000\n\nDear AWS Customer,\n\nYou requested that we alert you when the ACTUAL Cost associated with your monthly-total budget is greater than $800.00 for the current month. The ACTUAL Cost associated with this budget is $1,050.50. You can find additional details below and by accessing the AWS Budgets dashboard [1].\n\nBudget Name: monthly-total\nBudget Type: Cost\nBudgeted Amount: $1,000.00\nAlert Type: ACTUAL\nAlert Threshold: > $800.00\nACTUAL Amount: $1,050.50\n\n[1] https://console.aws.amazon.com/billing/home#/budgets\n")
+
+var budgetJSONEvent = testSNSEvent("{\"accountId\":\"123456789000\",\"budgetName\":\"monthly-total\",\"budgetType\":\"COST\",\"alertType\":\"FORECASTED\",\"alertThreshold\":\"80%\",\"budgetedAmount\":1000,\"forecastedAmount\":\"850.00\"}")
+
+var costAnomalyEvent = testSNSEvent("{\"accountId\":\"123456789000\",\"anomalyDetailsLink\":\"https://console.aws.amazon.com/cost-management/home#/anomaly-detection/monitors/abc/anomalies/def\",\"anomalyEndDate\":\"2022-05-03T00:00:00Z\",\"anomalyId\":\"def\",\"anomalyScore\":{\"currentScore\":0.9,\"maxScore\":0.9},\"anomalyStartDate\":\"2022-05-02T00:00:00Z\",\"dimensionalValue\":\"AmazonEC2\",\"impact\":{\"maxImpact\":151,\"totalActualSpend\":1301,\"totalExpectedSpend\":1150,\"totalImpact\":151,\"totalImpactPercentage\":13.13},\"monitorArn\":\"arn:aws:ce::123456789000:anomalymonitor/abc\",\"monitorName\":\"services\",\"monitorType\":\"DIMENSIONAL\",\"rootCauses\":[{\"linkedAccount\":\"123456789000\",\"linkedAccountName\":\"production\",\"region\":\"eu-west-1\",\"service\":\"Amazon Elastic Compute Cloud - Compute\",\"usageType\":\"BoxUsage:m5.large\"}],\"subscriptionId\":\"ghi\",\"subscriptionName\":\"finops\"}")
+
+func TestCreateSlackMessageAttachmentForBudgetTextEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(budgetTextEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Budget",
+			Value: "monthly-total",
+			Short: true,
+		},
+		{
+			Title: "Budget type",
+			Value: "Cost",
+			Short: true,
+		},
+		{
+			Title: "Budgeted amount",
+			Value: "$1,000.00",
+			Short: true,
+		},
+		{
+			Title: "ACTUAL amount",
+			Value: "$1,050.50",
+			Short: true,
+		},
+		{
+			Title: "Alert threshold",
+			Value: "> $800.00",
+			Short: true,
+		},
+		{
+			Title: "Usage",
+			Value: "5.0% over budget",
+			Short: true,
+		},
+		{
+			Title: "Budgets",
+			Value: "<https://console.aws.amazon.com/billing/home#/budgets|Budgets>",
+			Short: true,
+		},
+		{
+			Title: "Cost Explorer",
+			Value: "<https://console.aws.amazon.com/cost-management/home#/cost-explorer|Cost Explorer>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Budget monthly-total: ACTUAL amount $1,050.50 of $1,000.00", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForBudgetJSONEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(budgetJSONEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Budget monthly-total: FORECASTED amount $850.00 of $1,000.00", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Usage", Value: "85.0% of budget", Short: true}, msg.Fields[5])
+}
+
+func TestCreateSlackMessageAttachmentForBudgetJSONEventThreshold(t *testing.T) {
+	for message, threshold := range map[string]string{
+		"{\"budgetName\":\"monthly-total\",\"alertType\":\"ACTUAL\",\"alertThreshold\":\"80%\",\"budgetedAmount\":1000,\"actualAmount\":850}": "80%",
+		"{\"budgetName\":\"monthly-total\",\"alertType\":\"ACTUAL\",\"alertThreshold\":80,\"budgetedAmount\":1000,\"actualAmount\":850}":      "80",
+		"{\"budgetName\":\"monthly-total\",\"alertType\":\"ACTUAL\",\"budgetedAmount\":1000,\"actualAmount\":850}":                            "",
+	} {
+		notification := CreateNotification(testSNSEvent(message))
+
+		for _, field := range notification.Fields {
+			if field.Title == "Alert threshold" {
+				assert.Equal(t, threshold, field.Value, message)
+			}
+		}
+	}
+}
+
+func TestCreateSlackMessageAttachmentForCostAnomalyEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(costAnomalyEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Monitor",
+			Value: "services",
+			Short: true,
+		},
+		{
+			Title: "Impact",
+			Value: "$151.00 (13.1%)",
+			Short: true,
+		},
+		{
+			Title: "Actual spend",
+			Value: "$1,301.00",
+			Short: true,
+		},
+		{
+			Title: "Expected spend",
+			Value: "$1,150.00",
+			Short: true,
+		},
+		{
+			Title: "Start date",
+			Value: "2022-05-02T00:00:00Z",
+			Short: true,
+		},
+		{
+			Title: "End date",
+			Value: "2022-05-03T00:00:00Z",
+			Short: true,
+		},
+		{
+			Title: "Root causes",
+			Value: "Amazon Elastic Compute Cloud - Compute / 123456789000 / eu-west-1 / BoxUsage:m5.large",
+			Short: false,
+		},
+		{
+			Title: "Anomaly",
+			Value: "<https://console.aws.amazon.com/cost-management/home#/anomaly-detection/monitors/abc/anomalies/def|Anomaly>",
+			Short: true,
+		},
+		{
+			Title: "Cost Explorer",
+			Value: "<https://console.aws.amazon.com/cost-management/home#/cost-explorer|Cost Explorer>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Cost anomaly detected by services: $151.00 impact", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestFormatDollars(t *testing.T) {
+	assert.Equal(t, "$0.50", formatDollars(0.5))
+	assert.Equal(t, "$999.99", formatDollars(999.99))
+	assert.Equal(t, "$1,234,567.89", formatDollars(1234567.891))
+	assert.Equal(t, "-$1,000.00", formatDollars(-1000))
+}
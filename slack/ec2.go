@@ -0,0 +1,168 @@
+package slack
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
+
+// spotInterruptionNotice is how long before the interruption EC2 sends the
+// Spot interruption warning
+const spotInterruptionNotice = 2 * time.Minute
+
+// now is replaced in tests
+var now = time.Now
+
+// InstanceNamer resolves EC2 instance IDs to the value of their Name tag
+type InstanceNamer interface {
+	InstanceName(instanceID string) (string, error)
+}
+
+// StaticInstanceNames resolves instance names from a fixed map
+type StaticInstanceNames map[string]string
+
+// InstanceName returns the name configured for instanceID, if any
+func (n StaticInstanceNames) InstanceName(instanceID string) (string, error) {
+	return n[instanceID], nil
+}
+
+// ParseStaticInstanceNames parses a comma separated list of id=name pairs
+func ParseStaticInstanceNames(list string) StaticInstanceNames {
+	names := StaticInstanceNames{}
+	for _, pair := range commaList(list) {
+		if i := strings.Index(pair, "="); i > 0 {
+			names[strings.TrimSpace(pair[:i])] = strings.TrimSpace(pair[i+1:])
+		}
+	}
+
+	return names
+}
+
+// InstanceNames resolves the names shown next to EC2 instance IDs. It
+// defaults to the static EC2_INSTANCE_NAMES map.
+var InstanceNames InstanceNamer = ParseStaticInstanceNames(os.Getenv("EC2_INSTANCE_NAMES"))
+
+// commaList returns the trimmed, non-empty items of a comma separated setting
+func commaList(list string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// instanceLabel returns the instance ID followed by its name when known
+func instanceLabel(instanceID string) string {
+	name, err := InstanceNames.InstanceName(instanceID)
+	if err != nil {
+		log.Println("Error resolving instance name", instanceID, err)
+	}
+
+	if name == "" {
+		return instanceID
+	}
+
+	return fmt.Sprintf("%s (%s)", instanceID, name)
+}
+
+func ec2StateSeverity(state string) Severity {
+	switch state {
+	case "stopping", "stopped", "shutting-down", "terminated":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+func ec2InstanceStateChange(message *gabs.Container) *Notification {
+	instanceID, _ := message.Path("detail.instance-id").Data().(string)
+	state, _ := message.Path("detail.state").Data().(string)
+	instance := instanceLabel(instanceID)
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Instance %s is %s", instance, state)
+	notification.Severity = ec2StateSeverity(state)
+	notification.State = state
+	notification.Fields = []Field{
+		{
+			Title: "Instance",
+			Value: instance,
+			Short: true,
+		},
+		{
+			Title: "State",
+			Value: state,
+			Short: true,
+		},
+	}
+
+	return &notification
+}
+
+func ec2SpotInterruption(message *gabs.Container) *Notification {
+	instanceID, _ := message.Path("detail.instance-id").Data().(string)
+	action, _ := message.Path("detail.instance-action").Data().(string)
+	instance := instanceLabel(instanceID)
+
+	notification := eventBridgeNotification(message)
+
+	interruptAt := notification.Timestamp.Add(spotInterruptionNotice)
+	remaining := interruptAt.Sub(now()).Round(time.Second)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	notification.Title = fmt.Sprintf("Spot instance %s will be interrupted (%s)", instance, action)
+	notification.Severity = SeverityCritical
+	notification.State = action
+	notification.Fields = []Field{
+		{
+			Title: "Instance",
+			Value: instance,
+			Short: true,
+		},
+		{
+			Title: "Action",
+			Value: action,
+			Short: true,
+		},
+		{
+			Title: "Interruption time",
+			Value: formatTime(interruptAt),
+			Short: true,
+		},
+		{
+			Title: "Time remaining",
+			Value: remaining.String(),
+			Short: true,
+		},
+	}
+
+	return &notification
+}
+
+func ec2RebalanceRecommendation(message *gabs.Container) *Notification {
+	instanceID, _ := message.Path("detail.instance-id").Data().(string)
+	instance := instanceLabel(instanceID)
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Spot instance %s is at elevated risk of interruption", instance)
+	notification.Severity = SeverityWarning
+	notification.State = "rebalance"
+	notification.Fields = []Field{
+		{
+			Title: "Instance",
+			Value: instance,
+			Short: true,
+		},
+	}
+
+	return &notification
+}
@@ -0,0 +1,127 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var stoppedEc2InstanceEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"7bf73129-1428-4cd3-a780-95db273d1602\",\"detail-type\":\"EC2 Instance State-change Notification\",\"source\":\"aws.ec2\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ec2:eu-west-1:123456789000:instance/i-0123456789abcdef0\"],\"detail\":{\"instance-id\":\"i-0123456789abcdef0\",\"state\":\"stopped\"}}")
+
+var ec2SpotInterruptionEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"1e5527d7-bb36-4607-3370-4164db56a40e\",\"detail-type\":\"EC2 Spot Instance Interruption Warning\",\"source\":\"aws.ec2\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ec2:eu-west-1b:instance/i-0123456789abcdef1\"],\"detail\":{\"instance-id\":\"i-0123456789abcdef1\",\"instance-action\":\"terminate\"}}")
+
+var ec2RebalanceRecommendationEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"2e5527d7-bb36-4607-3370-4164db56a40e\",\"detail-type\":\"EC2 Instance Rebalance Recommendation\",\"source\":\"aws.ec2\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ec2:eu-west-1b:instance/i-0123456789abcdef1\"],\"detail\":{\"instance-id\":\"i-0123456789abcdef1\"}}")
+
+type fakeDescribeTags struct {
+	calls int
+}
+
+func (f *fakeDescribeTags) DescribeTags(ctx context.Context, params *ec2.DescribeTagsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error) {
+	f.calls++
+	return &ec2.DescribeTagsOutput{
+		Tags: []types.TagDescription{
+			{
+				Key:        aws.String("Name"),
+				ResourceId: aws.String(params.Filters[0].Values[0]),
+				Value:      aws.String("worker"),
+			},
+		},
+	}, nil
+}
+
+func TestCreateSlackMessageAttachmentForStoppedEc2InstanceEvent(t *testing.T) {
+	defer func(names InstanceNamer) { InstanceNames = names }(InstanceNames)
+	InstanceNames = ParseStaticInstanceNames("i-0123456789abcdef0=web-1, i-0123456789abcdef1=web-2")
+
+	slackMessageAttachments := CreateSlackMessageAttachment(stoppedEc2InstanceEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Instance",
+			Value: "i-0123456789abcdef0 (web-1)",
+			Short: true,
+		},
+		{
+			Title: "State",
+			Value: "stopped",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Instance i-0123456789abcdef0 (web-1) is stopped", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForEc2SpotInterruptionEvent(t *testing.T) {
+	defer func(clock func() time.Time) { now = clock }(now)
+	now = func() time.Time { return time.Date(2022, 5, 3, 7, 29, 35, 0, time.UTC) }
+
+	slackMessageAttachments := CreateSlackMessageAttachment(ec2SpotInterruptionEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Instance",
+			Value: "i-0123456789abcdef1",
+			Short: true,
+		},
+		{
+			Title: "Action",
+			Value: "terminate",
+			Short: true,
+		},
+		{
+			Title: "Interruption time",
+			Value: "2022-05-03 07:31 UTC",
+			Short: true,
+		},
+		{
+			Title: "Time remaining",
+			Value: "1m45s",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Spot instance i-0123456789abcdef1 will be interrupted (terminate)", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForEc2RebalanceRecommendationEvent(t *testing.T) {
+	defer func(names InstanceNamer) { InstanceNames = names }(InstanceNames)
+	InstanceNames = NewEC2InstanceNames(&fakeDescribeTags{})
+
+	slackMessageAttachments := CreateSlackMessageAttachment(ec2RebalanceRecommendationEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Spot instance i-0123456789abcdef1 (worker) is at elevated risk of interruption", msg.Pretext)
+}
+
+func TestEC2InstanceNamesCachesLookups(t *testing.T) {
+	client := &fakeDescribeTags{}
+	names := NewEC2InstanceNames(client)
+
+	for i := 0; i < 2; i++ {
+		name, err := names.InstanceName("i-0123456789abcdef0")
+		assert.NoError(t, err)
+		assert.Equal(t, "worker", name)
+	}
+
+	assert.Equal(t, 1, client.calls)
+}
@@ -0,0 +1,66 @@
+package slack
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// EC2DescribeTagsAPI is the part of the EC2 client used to look up Name tags
+type EC2DescribeTagsAPI interface {
+	DescribeTags(ctx context.Context, params *ec2.DescribeTagsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error)
+}
+
+// EC2InstanceNames resolves instance names through the EC2 API and caches
+// them across invocations
+type EC2InstanceNames struct {
+	client EC2DescribeTagsAPI
+
+	mu    sync.Mutex
+	names map[string]string
+}
+
+// NewEC2InstanceNames returns an InstanceNamer backed by the EC2 API
+func NewEC2InstanceNames(client EC2DescribeTagsAPI) *EC2InstanceNames {
+	return &EC2InstanceNames{
+		client: client,
+		names:  map[string]string{},
+	}
+}
+
+// InstanceName looks up the Name tag of instanceID
+func (n *EC2InstanceNames) InstanceName(instanceID string) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if name, ok := n.names[instanceID]; ok {
+		return name, nil
+	}
+
+	output, err := n.client.DescribeTags(context.TODO(), &ec2.DescribeTagsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("resource-id"),
+				Values: []string{instanceID},
+			},
+			{
+				Name:   aws.String("key"),
+				Values: []string{"Name"},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var name string
+	if len(output.Tags) > 0 {
+		name = aws.ToString(output.Tags[0].Value)
+	}
+	n.names[instanceID] = name
+
+	return name, nil
+}
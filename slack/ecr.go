@@ -0,0 +1,186 @@
+package slack
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// ecrSeverities are the ECR finding severities, most severe first
+var ecrSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNDEFINED"}
+
+// ecrScanMinSeverity reads ECR_SCAN_MIN_SEVERITY and returns the index in
+// ecrSeverities a scan needs findings at or above to be posted
+func ecrScanMinSeverity() int {
+	minimum := strings.ToUpper(os.Getenv("ECR_SCAN_MIN_SEVERITY"))
+	for i, severity := range ecrSeverities {
+		if severity == minimum {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func ecrScanSeverity(counts map[string]float64) Severity {
+	switch {
+	case counts["CRITICAL"] > 0 || counts["HIGH"] > 0:
+		return SeverityCritical
+	case counts["MEDIUM"] > 0:
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+func ecrScanResultsURL(region, account, repository, digest string) string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/ecr/repositories/private/%s/%s/_/image/%s/scan-results?region=%s",
+		region, account, repository, digest, region)
+}
+
+func ecrImageScan(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	status, _ := detail.Path("scan-status").Data().(string)
+	repository, _ := detail.Path("repository-name").Data().(string)
+	digest, _ := detail.Path("image-digest").Data().(string)
+
+	var tags []string
+	imageTags, _ := detail.Path("image-tags").Children()
+	for _, tag := range imageTags {
+		if value, ok := tag.Data().(string); ok {
+			tags = append(tags, value)
+		}
+	}
+
+	counts := map[string]float64{}
+	var findings []string
+	for _, severity := range ecrSeverities {
+		if count, ok := detail.Path("finding-severity-counts." + severity).Data().(float64); ok && count > 0 {
+			counts[severity] = count
+			findings = append(findings, fmt.Sprintf("%s: %.0f", severity, count))
+		}
+	}
+
+	if minimum := ecrScanMinSeverity(); minimum >= 0 && status == "COMPLETE" {
+		posted := false
+		for _, severity := range ecrSeverities[:minimum+1] {
+			if counts[severity] > 0 {
+				posted = true
+			}
+		}
+		if !posted {
+			log.Println("Skipping ECR image scan below minimum severity", repository, digest, strings.Join(findings, ", "))
+			return nil
+		}
+	}
+
+	if len(findings) == 0 {
+		findings = []string{"None"}
+	}
+
+	notification := eventBridgeNotification(message)
+
+	image := repository
+	if len(tags) > 0 {
+		image += ":" + tags[0]
+	}
+
+	severity := ecrScanSeverity(counts)
+	if status != "COMPLETE" && severity == SeverityOK {
+		severity = SeverityWarning
+	}
+
+	notification.Title = fmt.Sprintf("Image scan of %s %s", image, status)
+	notification.Severity = severity
+	notification.State = status
+	notification.Resource = repository
+	notification.Fields = []Field{
+		{
+			Title: "Repository",
+			Value: repository,
+			Short: true,
+		},
+		{
+			Title: "Tags",
+			Value: strings.Join(tags, ", "),
+			Short: true,
+		},
+		{
+			Title: "Findings",
+			Value: strings.Join(findings, ", "),
+			Short: false,
+		},
+		{
+			Title: "Digest",
+			Value: digest,
+			Short: false,
+		},
+	}
+	notification.Links = []Link{
+		{
+			Title: "Scan results",
+			URL:   ecrScanResultsURL(notification.Region, notification.Account, repository, digest),
+		},
+	}
+
+	return &notification
+}
+
+func ecrImageAction(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	result, _ := detail.Path("result").Data().(string)
+	action, _ := detail.Path("action-type").Data().(string)
+	repository, _ := detail.Path("repository-name").Data().(string)
+	digest, _ := detail.Path("image-digest").Data().(string)
+	tag, _ := detail.Path("image-tag").Data().(string)
+
+	image := repository
+	if tag != "" {
+		image += ":" + tag
+	}
+
+	severity := SeverityOK
+	if result != "SUCCESS" {
+		severity = SeverityCritical
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Image %s of %s %s", action, image, result)
+	notification.Severity = severity
+	notification.State = result
+	notification.Resource = repository
+	notification.Fields = []Field{
+		{
+			Title: "Repository",
+			Value: repository,
+			Short: true,
+		},
+		{
+			Title: "Tag",
+			Value: tag,
+			Short: true,
+		},
+		{
+			Title: "Action",
+			Value: action,
+			Short: true,
+		},
+		{
+			Title: "Result",
+			Value: result,
+			Short: true,
+		},
+		{
+			Title: "Digest",
+			Value: digest,
+			Short: false,
+		},
+	}
+
+	return &notification
+}
@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ecrImageScanEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"85fc3613-e913-7fc4-a80c-a3753e4aa9ae\",\"detail-type\":\"ECR Image Scan\",\"source\":\"aws.ecr\",\"account\":\"123456789000\",\"time\":\"2022-05-03T09:12:00Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ecr:eu-west-1:123456789000:repository/api\"],\"detail\":{\"scan-status\":\"COMPLETE\",\"repository-name\":\"api\",\"finding-severity-counts\":{\"CRITICAL\":2,\"HIGH\":5,\"MEDIUM\":9},\"image-digest\":\"sha256:7f5b2640fe6fb4f46592dfd3410c4a79dac4f89e4782432e0378abcd1234\",\"image-tags\":[\"v1.2.0\"]}}")
+
+var cleanEcrImageScanEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"95fc3613-e913-7fc4-a80c-a3753e4aa9ae\",\"detail-type\":\"ECR Image Scan\",\"source\":\"aws.ecr\",\"account\":\"123456789000\",\"time\":\"2022-05-03T09:12:00Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ecr:eu-west-1:123456789000:repository/api\"],\"detail\":{\"scan-status\":\"COMPLETE\",\"repository-name\":\"api\",\"finding-severity-counts\":{\"MEDIUM\":1},\"image-digest\":\"sha256:8f5b2640fe6fb4f46592dfd3410c4a79dac4f89e4782432e0378abcd1234\",\"image-tags\":[\"v1.2.1\"]}}")
+
+var ecrImagePushEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"13cde686-328b-6117-af20-0e5566167482\",\"detail-type\":\"ECR Image Action\",\"source\":\"aws.ecr\",\"account\":\"123456789000\",\"time\":\"2022-05-03T09:10:00Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"result\":\"SUCCESS\",\"repository-name\":\"api\",\"image-digest\":\"sha256:7f5b2640fe6fb4f46592dfd3410c4a79dac4f89e4782432e0378abcd1234\",\"action-type\":\"PUSH\",\"image-tag\":\"v1.2.0\"}}")
+
+func TestCreateSlackMessageAttachmentForEcrImageScanEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ecrImageScanEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Repository",
+			Value: "api",
+			Short: true,
+		},
+		{
+			Title: "Tags",
+			Value: "v1.2.0",
+			Short: true,
+		},
+		{
+			Title: "Findings",
+			Value: "CRITICAL: 2, HIGH: 5, MEDIUM: 9",
+			Short: false,
+		},
+		{
+			Title: "Digest",
+			Value: "sha256:7f5b2640fe6fb4f46592dfd3410c4a79dac4f89e4782432e0378abcd1234",
+			Short: false,
+		},
+		{
+			Title: "Scan results",
+			Value: "<https://eu-west-1.console.aws.amazon.com/ecr/repositories/private/123456789000/api/_/image/sha256:7f5b2640fe6fb4f46592dfd3410c4a79dac4f89e4782432e0378abcd1234/scan-results?region=eu-west-1|Scan results>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Image scan of api:v1.2.0 COMPLETE", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestEcrImageScanMinSeverity(t *testing.T) {
+	t.Setenv("ECR_SCAN_MIN_SEVERITY", "HIGH")
+
+	assert.NotEqual(t, "", CreateSlackMessageAttachment(ecrImageScanEvent))
+	assert.Equal(t, "", CreateSlackMessageAttachment(cleanEcrImageScanEvent))
+}
+
+func TestCreateSlackMessageAttachmentForEcrImagePushEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ecrImagePushEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Image PUSH of api:v1.2.0 SUCCESS", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Action", Value: "PUSH", Short: true}, msg.Fields[2])
+}
@@ -0,0 +1,126 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// ecsResource returns the integer value of the named CPU or MEMORY resource
+func ecsResource(resources *gabs.Container, name string) (float64, bool) {
+	children, _ := resources.Children()
+	for _, resource := range children {
+		if resourceName, _ := resource.Path("name").Data().(string); resourceName == name {
+			value, ok := resource.Path("integerValue").Data().(float64)
+			return value, ok
+		}
+	}
+
+	return 0, false
+}
+
+// ecsResourceUsage renders the remaining and registered amount of a resource
+// as `remaining / registered`
+func ecsResourceUsage(detail *gabs.Container, name, unit string) string {
+	remaining, _ := ecsResource(detail.Path("remainingResources"), name)
+	registered, ok := ecsResource(detail.Path("registeredResources"), name)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%.0f / %.0f %s", remaining, registered, unit))
+}
+
+func ecsContainerInstanceStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	clusterArn, _ := detail.Path("clusterArn").Data().(string)
+	containerInstanceArn, _ := detail.Path("containerInstanceArn").Data().(string)
+	instanceID, _ := detail.Path("ec2InstanceId").Data().(string)
+	status, _ := detail.Path("status").Data().(string)
+	statusReason, _ := detail.Path("statusReason").Data().(string)
+	agentConnected, _ := detail.Path("agentConnected").Data().(bool)
+	agentVersion, _ := detail.Path("versionInfo.agentVersion").Data().(string)
+
+	clusterName := clusterArn[strings.LastIndex(clusterArn, "/")+1:]
+	instance := instanceLabel(instanceID)
+
+	fields := []Field{
+		{
+			Title: "Cluster",
+			Value: clusterName,
+			Short: true,
+		},
+		{
+			Title: "Instance",
+			Value: instance,
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+		{
+			Title: "Agent connected",
+			Value: fmt.Sprintf("%t", agentConnected),
+			Short: true,
+		},
+	}
+
+	for _, resource := range []struct{ title, name, unit string }{
+		{"CPU remaining", "CPU", "units"},
+		{"Memory remaining", "MEMORY", "MiB"},
+	} {
+		if usage := ecsResourceUsage(detail, resource.name, resource.unit); usage != "" {
+			fields = append(fields, Field{
+				Title: resource.title,
+				Value: usage,
+				Short: true,
+			})
+		}
+	}
+
+	if agentVersion != "" {
+		fields = append(fields, Field{
+			Title: "Agent version",
+			Value: agentVersion,
+			Short: true,
+		})
+	}
+
+	if statusReason != "" {
+		fields = append(fields, Field{
+			Title: "Status reason",
+			Value: statusReason,
+			Short: false,
+		})
+	}
+
+	fields = append(fields, Field{
+		Title: "Container instance",
+		Value: containerInstanceArn,
+		Short: false,
+	})
+
+	notification := eventBridgeNotification(message)
+
+	switch {
+	case !agentConnected && status == "ACTIVE":
+		notification.Title = fmt.Sprintf("ECS agent on %s in %s cluster disconnected", instance, clusterName)
+		notification.Severity = SeverityCritical
+	case status == "ACTIVE":
+		notification.Title = fmt.Sprintf("Container instance %s in %s cluster is ACTIVE", instance, clusterName)
+		notification.Severity = SeverityOK
+	default:
+		notification.Title = fmt.Sprintf("Container instance %s in %s cluster is %s", instance, clusterName, status)
+		notification.Severity = SeverityWarning
+	}
+
+	notification.State = status
+	notification.Resource = containerInstanceArn
+	notification.Fields = fields
+
+	return &notification
+}
@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ecsAgentDisconnectedEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"8952ba83-7be2-4ab5-9c32-6687532d15a2\",\"detail-type\":\"ECS Container Instance State Change\",\"source\":\"aws.ecs\",\"account\":\"123456789000\",\"time\":\"2022-05-03T13:00:00Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ecs:eu-west-1:123456789000:container-instance/prod/b54a2a04-046f-4331-9d74-3f6d7f6ca315\"],\"detail\":{\"agentConnected\":false,\"attributes\":[{\"name\":\"com.amazonaws.ecs.capability.docker-remote-api.1.17\"}],\"clusterArn\":\"arn:aws:ecs:eu-west-1:123456789000:cluster/prod\",\"containerInstanceArn\":\"arn:aws:ecs:eu-west-1:123456789000:container-instance/prod/b54a2a04-046f-4331-9d74-3f6d7f6ca315\",\"ec2InstanceId\":\"i-0abcdef1234567890\",\"registeredResources\":[{\"name\":\"CPU\",\"type\":\"INTEGER\",\"integerValue\":2048},{\"name\":\"MEMORY\",\"type\":\"INTEGER\",\"integerValue\":3767},{\"name\":\"PORTS\",\"type\":\"STRINGSET\",\"stringSetValue\":[\"22\",\"2375\"]}],\"remainingResources\":[{\"name\":\"CPU\",\"type\":\"INTEGER\",\"integerValue\":1024},{\"name\":\"MEMORY\",\"type\":\"INTEGER\",\"integerValue\":2743},{\"name\":\"PORTS\",\"type\":\"STRINGSET\",\"stringSetValue\":[\"22\",\"2375\"]}],\"status\":\"ACTIVE\",\"version\":14801,\"versionInfo\":{\"agentHash\":\"aebcbca\",\"agentVersion\":\"1.63.1\",\"dockerVersion\":\"DockerVersion: 20.10.13\"},\"updatedAt\":\"2022-05-03T13:00:00.000Z\"}}")
+
+var ecsDrainingEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"9952ba83-7be2-4ab5-9c32-6687532d15a2\",\"detail-type\":\"ECS Container Instance State Change\",\"source\":\"aws.ecs\",\"account\":\"123456789000\",\"time\":\"2022-05-03T13:05:00Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"agentConnected\":true,\"clusterArn\":\"arn:aws:ecs:eu-west-1:123456789000:cluster/prod\",\"containerInstanceArn\":\"arn:aws:ecs:eu-west-1:123456789000:container-instance/prod/c54a2a04-046f-4331-9d74-3f6d7f6ca315\",\"ec2InstanceId\":\"i-0123456789abcdef0\",\"status\":\"DRAINING\"}}")
+
+func TestCreateSlackMessageAttachmentForEcsAgentDisconnectedEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ecsAgentDisconnectedEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Cluster",
+			Value: "prod",
+			Short: true,
+		},
+		{
+			Title: "Instance",
+			Value: "i-0abcdef1234567890",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "ACTIVE",
+			Short: true,
+		},
+		{
+			Title: "Agent connected",
+			Value: "false",
+			Short: true,
+		},
+		{
+			Title: "CPU remaining",
+			Value: "1024 / 2048 units",
+			Short: true,
+		},
+		{
+			Title: "Memory remaining",
+			Value: "2743 / 3767 MiB",
+			Short: true,
+		},
+		{
+			Title: "Agent version",
+			Value: "1.63.1",
+			Short: true,
+		},
+		{
+			Title: "Container instance",
+			Value: "arn:aws:ecs:eu-west-1:123456789000:container-instance/prod/b54a2a04-046f-4331-9d74-3f6d7f6ca315",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "ECS agent on i-0abcdef1234567890 in prod cluster disconnected", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForEcsDrainingEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ecsDrainingEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "Container instance i-0123456789abcdef0 in prod cluster is DRAINING", msg.Pretext)
+	assert.Equal(t, 5, len(msg.Fields))
+}
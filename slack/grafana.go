@@ -0,0 +1,90 @@
+package slack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+func isGrafana(message *gabs.Container) bool {
+	return message.Exists("alerts") && message.Exists("orgId")
+}
+
+// grafana parses a Grafana unified alerting webhook payload into the same
+// notification as CloudWatch alarms
+func grafana(message *gabs.Container) *Notification {
+	status, _ := message.Path("status").Data().(string)
+	groupKey, _ := message.Path("groupKey").Data().(string)
+	alerts, _ := message.Path("alerts").Children()
+
+	name, _ := message.Path("groupLabels.alertname").Data().(string)
+	if name == "" {
+		name, _ = message.Path("commonLabels.alertname").Data().(string)
+	}
+
+	reason, _ := message.Path("commonAnnotations.summary").Data().(string)
+	if reason == "" && len(alerts) > 0 {
+		reason, _ = alerts[0].Path("annotations.summary").Data().(string)
+	}
+
+	var fields []Field
+	var links []Link
+	for _, alert := range alerts {
+		if values := grafanaValues(alert); values != "" {
+			fields = append(fields, Field{
+				Title: "Values",
+				Value: values,
+				Short: false,
+			})
+		}
+
+		if description, _ := alert.Path("annotations.description").Data().(string); description != "" {
+			fields = append(fields, Field{
+				Title: "Description",
+				Value: description,
+				Short: false,
+			})
+		}
+
+		for _, link := range []struct{ title, path string }{
+			{"Dashboard", "dashboardURL"},
+			{"Panel", "panelURL"},
+			{"Silence", "silenceURL"},
+		} {
+			if url, _ := alert.Path(link.path).Data().(string); url != "" {
+				links = append(links, Link{Title: link.title, URL: url})
+			}
+		}
+	}
+
+	notification := alertNotification(name, strings.ToUpper(status), reason, "")
+	notification.Source = "grafana"
+	notification.Fields = append(notification.Fields, fields...)
+	notification.Links = links
+	notification.DedupKey = groupKey
+
+	return &notification
+}
+
+// grafanaValues renders the evaluated query values of an alert as `A=1, B=2`
+func grafanaValues(alert *gabs.Container) string {
+	values, err := alert.Path("values").ChildrenMap()
+	if err != nil || len(values) == 0 {
+		return ""
+	}
+
+	refIDs := make([]string, 0, len(values))
+	for refID := range values {
+		refIDs = append(refIDs, refID)
+	}
+	sort.Strings(refIDs)
+
+	pairs := make([]string, 0, len(refIDs))
+	for _, refID := range refIDs {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", refID, values[refID].Data()))
+	}
+
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,66 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var firingGrafanaEvent = testSNSEvent("{\"receiver\":\"sns\",\"status\":\"firing\",\"orgId\":1,\"alerts\":[{\"status\":\"firing\",\"labels\":{\"alertname\":\"HighCPU\",\"grafana_folder\":\"Infra\"},\"annotations\":{\"summary\":\"CPU above 90%\",\"description\":\"CPU usage on web-1 has been above 90% for 5 minutes\"},\"startsAt\":\"2022-05-03T07:29:20Z\",\"endsAt\":\"0001-01-01T00:00:00Z\",\"generatorURL\":\"https://grafana.example.com/alerting/grafana/abc/view\",\"fingerprint\":\"1234\",\"silenceURL\":\"https://grafana.example.com/alerting/silence/new?matcher=alertname%3DHighCPU\",\"dashboardURL\":\"https://grafana.example.com/d/abc\",\"panelURL\":\"https://grafana.example.com/d/abc?viewPanel=2\",\"values\":{\"C\":1,\"B\":93.5},\"valueString\":\"[ var='B' labels={} value=93.5 ], [ var='C' labels={} value=1 ]\"}],\"groupLabels\":{\"alertname\":\"HighCPU\"},\"commonLabels\":{\"alertname\":\"HighCPU\"},\"commonAnnotations\":{\"summary\":\"CPU above 90%\"},\"externalURL\":\"https://grafana.example.com/\",\"version\":\"1\",\"groupKey\":\"{}:{alertname=\\\"HighCPU\\\"}\",\"truncatedAlerts\":0,\"title\":\"[FIRING:1] HighCPU Infra\",\"state\":\"alerting\",\"message\":\"**Firing**\"}")
+
+func TestCreateSlackMessageAttachmentForFiringGrafanaEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(firingGrafanaEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Alarm",
+			Value: "HighCPU",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FIRING",
+			Short: true,
+		},
+		{
+			Title: "Reason",
+			Value: "CPU above 90%",
+			Short: false,
+		},
+		{
+			Title: "Values",
+			Value: "B=93.5, C=1",
+			Short: false,
+		},
+		{
+			Title: "Description",
+			Value: "CPU usage on web-1 has been above 90% for 5 minutes",
+			Short: false,
+		},
+		{
+			Title: "Dashboard",
+			Value: "<https://grafana.example.com/d/abc|Dashboard>",
+			Short: true,
+		},
+		{
+			Title: "Panel",
+			Value: "<https://grafana.example.com/d/abc?viewPanel=2|Panel>",
+			Short: true,
+		},
+		{
+			Title: "Silence",
+			Value: "<https://grafana.example.com/alerting/silence/new?matcher=alertname%3DHighCPU|Silence>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "FIRING: HighCPU", msg.Pretext)
+	assert.Equal(t, "AWS-bot", msg.Username)
+	assert.Equal(t, ":loudspeaker:", msg.Icon)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// guardDutySeverity maps the numeric GuardDuty severity to its label
+// (low < 4, medium < 7, high >= 7)
+func guardDutySeverity(severity float64) (Severity, string) {
+	switch {
+	case severity >= 7:
+		return SeverityCritical, "HIGH"
+	case severity >= 4:
+		return SeverityWarning, "MEDIUM"
+	default:
+		return SeverityOK, "LOW"
+	}
+}
+
+// guardDutyMinSeverity reads GUARDDUTY_MIN_SEVERITY (LOW, MEDIUM or HIGH)
+func guardDutyMinSeverity() Severity {
+	switch strings.ToUpper(os.Getenv("GUARDDUTY_MIN_SEVERITY")) {
+	case "HIGH":
+		return SeverityCritical
+	case "MEDIUM":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// guardDutyResource returns the most specific identifier of the affected resource
+func guardDutyResource(resource *gabs.Container) string {
+	for _, path := range []string{
+		"instanceDetails.instanceId",
+		"accessKeyDetails.userName",
+		"eksClusterDetails.name",
+		"ecsClusterDetails.name",
+		"lambdaDetails.functionName",
+	} {
+		if id, _ := resource.Path(path).Data().(string); id != "" {
+			return id
+		}
+	}
+
+	if buckets, _ := resource.Path("s3BucketDetails").Children(); len(buckets) > 0 {
+		name, _ := buckets[0].Path("name").Data().(string)
+		return name
+	}
+
+	return ""
+}
+
+func guardDutyFinding(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	findingType, _ := detail.Path("type").Data().(string)
+	title, _ := detail.Path("title").Data().(string)
+	description, _ := detail.Path("description").Data().(string)
+	score, _ := detail.Path("severity").Data().(float64)
+	findingArn, _ := detail.Path("arn").Data().(string)
+	resourceType, _ := detail.Path("resource.resourceType").Data().(string)
+	resource := guardDutyResource(detail.Path("resource"))
+
+	severity, label := guardDutySeverity(score)
+	if severity < guardDutyMinSeverity() {
+		log.Println("Skipping GuardDuty finding below minimum severity", findingType, score)
+		return nil
+	}
+
+	notification := eventBridgeNotification(message)
+
+	if resource != "" {
+		resourceType = resourceType + " " + resource
+	}
+
+	notification.Title = fmt.Sprintf("GuardDuty %s severity finding: %s", label, title)
+	notification.Severity = severity
+	notification.State = label
+	notification.Resource = findingArn
+	notification.Fields = []Field{
+		{
+			Title: "Finding type",
+			Value: findingType,
+			Short: true,
+		},
+		{
+			Title: "Severity",
+			Value: fmt.Sprintf("%s (%v)", label, score),
+			Short: true,
+		},
+		{
+			Title: "Resource",
+			Value: resourceType,
+			Short: true,
+		},
+		{
+			Title: "Account",
+			Value: notification.Account,
+			Short: true,
+		},
+		{
+			Title: "Region",
+			Value: notification.Region,
+			Short: true,
+		},
+		{
+			Title: "Description",
+			Value: description,
+			Short: false,
+		},
+	}
+
+	return &notification
+}
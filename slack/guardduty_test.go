@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var highGuardDutyFindingEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"c8c4daa7-a20c-2f03-0070-b7393dd542ad\",\"detail-type\":\"GuardDuty Finding\",\"source\":\"aws.guardduty\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"schemaVersion\":\"2.0\",\"accountId\":\"123456789000\",\"region\":\"eu-west-1\",\"partition\":\"aws\",\"id\":\"16afba5c5c43e07c9e3e5e2e544e95df\",\"arn\":\"arn:aws:guardduty:eu-west-1:123456789000:detector/123456/finding/16afba5c5c43e07c9e3e5e2e544e95df\",\"type\":\"CryptoCurrency:EC2/BitcoinTool.B!DNS\",\"resource\":{\"resourceType\":\"Instance\",\"instanceDetails\":{\"instanceId\":\"i-0123456789abcdef0\",\"instanceType\":\"m5.large\"}},\"severity\":8,\"createdAt\":\"2022-05-03T07:20:00.000Z\",\"updatedAt\":\"2022-05-03T07:29:20.000Z\",\"title\":\"Bitcoin-related domain name queried by EC2 instance i-0123456789abcdef0.\",\"description\":\"EC2 instance i-0123456789abcdef0 is querying a domain name that is associated with Bitcoin-related activity.\"}}")
+
+var lowGuardDutyFindingEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"c8c4daa7-a20c-2f03-0070-b7393dd542ae\",\"detail-type\":\"GuardDuty Finding\",\"source\":\"aws.guardduty\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"schemaVersion\":\"2.0\",\"accountId\":\"123456789000\",\"region\":\"eu-west-1\",\"id\":\"26afba5c5c43e07c9e3e5e2e544e95df\",\"arn\":\"arn:aws:guardduty:eu-west-1:123456789000:detector/123456/finding/26afba5c5c43e07c9e3e5e2e544e95df\",\"type\":\"Recon:IAMUser/NetworkPermissions\",\"resource\":{\"resourceType\":\"AccessKey\",\"accessKeyDetails\":{\"accessKeyId\":\"ASIAEXAMPLE\",\"userName\":\"deployer\"}},\"severity\":2.5,\"title\":\"Unusual network permission reconnaissance from deployer.\",\"description\":\"APIs commonly used to discover the network access permissions were invoked by deployer.\"}}")
+
+func TestCreateSlackMessageAttachmentForHighGuardDutyFindingEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(highGuardDutyFindingEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Finding type",
+			Value: "CryptoCurrency:EC2/BitcoinTool.B!DNS",
+			Short: true,
+		},
+		{
+			Title: "Severity",
+			Value: "HIGH (8)",
+			Short: true,
+		},
+		{
+			Title: "Resource",
+			Value: "Instance i-0123456789abcdef0",
+			Short: true,
+		},
+		{
+			Title: "Account",
+			Value: "123456789000",
+			Short: true,
+		},
+		{
+			Title: "Region",
+			Value: "eu-west-1",
+			Short: true,
+		},
+		{
+			Title: "Description",
+			Value: "EC2 instance i-0123456789abcdef0 is querying a domain name that is associated with Bitcoin-related activity.",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "GuardDuty HIGH severity finding: Bitcoin-related domain name queried by EC2 instance i-0123456789abcdef0.", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForLowGuardDutyFindingEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(lowGuardDutyFindingEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, slackAttachmentField{Title: "Resource", Value: "AccessKey deployer", Short: true}, msg.Fields[2])
+}
+
+func TestCreateSlackMessageAttachmentForGuardDutyFindingBelowMinSeverity(t *testing.T) {
+	t.Setenv("GUARDDUTY_MIN_SEVERITY", "MEDIUM")
+
+	assert.Equal(t, "", CreateSlackMessageAttachment(lowGuardDutyFindingEvent))
+	assert.NotEqual(t, "", CreateSlackMessageAttachment(highGuardDutyFindingEvent))
+}
@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
+
+func healthSeverity(category, status string) Severity {
+	switch {
+	case status == "closed":
+		return SeverityOK
+	case category == "issue":
+		return SeverityCritical
+	case category == "scheduledChange":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// healthTime formats the RFC1123 timestamps of AWS Health events in the
+// configured timezone
+func healthTime(value string) string {
+	for _, layout := range []string{time.RFC1123, time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return formatTime(t)
+		}
+	}
+
+	return value
+}
+
+func healthEvent(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	eventArn, _ := detail.Path("eventArn").Data().(string)
+	service, _ := detail.Path("service").Data().(string)
+	eventTypeCode, _ := detail.Path("eventTypeCode").Data().(string)
+	category, _ := detail.Path("eventTypeCategory").Data().(string)
+	status, _ := detail.Path("statusCode").Data().(string)
+	startTime, _ := detail.Path("startTime").Data().(string)
+	endTime, _ := detail.Path("endTime").Data().(string)
+
+	var description string
+	if descriptions, _ := detail.Path("eventDescription").Children(); len(descriptions) > 0 {
+		description, _ = descriptions[0].Path("latestDescription").Data().(string)
+	}
+
+	var entities []string
+	affectedEntities, _ := detail.Path("affectedEntities").Children()
+	for _, entity := range affectedEntities {
+		if value, _ := entity.Path("entityValue").Data().(string); value != "" {
+			entities = append(entities, value)
+		}
+	}
+
+	fields := []Field{
+		{
+			Title: "Service",
+			Value: service,
+			Short: true,
+		},
+		{
+			Title: "Event type",
+			Value: eventTypeCode,
+			Short: true,
+		},
+		{
+			Title: "Category",
+			Value: category,
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+	}
+
+	if startTime != "" {
+		fields = append(fields, Field{
+			Title: "Start time",
+			Value: healthTime(startTime),
+			Short: true,
+		})
+	}
+
+	if endTime != "" {
+		fields = append(fields, Field{
+			Title: "End time",
+			Value: healthTime(endTime),
+			Short: true,
+		})
+	}
+
+	if len(entities) > 0 {
+		fields = append(fields, Field{
+			Title: "Affected entities",
+			Value: strings.Join(entities, ", "),
+			Short: false,
+		})
+	}
+
+	fields = append(fields, Field{
+		Title: "Description",
+		Value: description,
+		Short: false,
+	})
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("AWS Health %s: %s %s (%s)", category, service, eventTypeCode, status)
+	notification.Severity = healthSeverity(category, status)
+	notification.State = status
+	notification.Resource = eventArn
+	notification.Fields = fields
+
+	return &notification
+}
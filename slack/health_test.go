@@ -0,0 +1,79 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var scheduledHealthEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"7bf73129-1428-4cd3-a780-95db273d1602\",\"detail-type\":\"AWS Health Event\",\"source\":\"aws.health\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"i-0123456789abcdef0\"],\"detail\":{\"eventArn\":\"arn:aws:health:eu-west-1::event/EC2/AWS_EC2_INSTANCE_REBOOT_MAINTENANCE_SCHEDULED/AWS_EC2_INSTANCE_REBOOT_MAINTENANCE_SCHEDULED_123\",\"service\":\"EC2\",\"eventScopeCode\":\"ACCOUNT_SPECIFIC\",\"communicationId\":\"123\",\"lastUpdatedTime\":\"Tue, 03 May 2022 07:29:20 GMT\",\"statusCode\":\"upcoming\",\"eventRegion\":\"eu-west-1\",\"eventTypeCode\":\"AWS_EC2_INSTANCE_REBOOT_MAINTENANCE_SCHEDULED\",\"eventTypeCategory\":\"scheduledChange\",\"startTime\":\"Sat, 14 May 2022 22:00:00 GMT\",\"endTime\":\"Sun, 15 May 2022 00:00:00 GMT\",\"eventDescription\":[{\"language\":\"en_US\",\"latestDescription\":\"One or more of your EC2 instances is scheduled for a reboot.\"}],\"affectedEntities\":[{\"entityValue\":\"i-0123456789abcdef0\"},{\"entityValue\":\"i-0123456789abcdef1\"}]}}")
+
+var issueHealthEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"7bf73129-1428-4cd3-a780-95db273d1603\",\"detail-type\":\"AWS Health Event\",\"source\":\"aws.health\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"eventArn\":\"arn:aws:health:eu-west-1::event/RDS/AWS_RDS_OPERATIONAL_ISSUE/AWS_RDS_OPERATIONAL_ISSUE_123\",\"service\":\"RDS\",\"statusCode\":\"open\",\"eventTypeCode\":\"AWS_RDS_OPERATIONAL_ISSUE\",\"eventTypeCategory\":\"issue\",\"startTime\":\"Tue, 03 May 2022 07:00:00 GMT\",\"eventDescription\":[{\"language\":\"en_US\",\"latestDescription\":\"We are investigating increased API error rates.\"}]}}")
+
+func TestCreateSlackMessageAttachmentForScheduledHealthEvent(t *testing.T) {
+	t.Setenv("TIMEZONE", "Europe/Oslo")
+
+	slackMessageAttachments := CreateSlackMessageAttachment(scheduledHealthEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Service",
+			Value: "EC2",
+			Short: true,
+		},
+		{
+			Title: "Event type",
+			Value: "AWS_EC2_INSTANCE_REBOOT_MAINTENANCE_SCHEDULED",
+			Short: true,
+		},
+		{
+			Title: "Category",
+			Value: "scheduledChange",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "upcoming",
+			Short: true,
+		},
+		{
+			Title: "Start time",
+			Value: "2022-05-15 00:00 CEST",
+			Short: true,
+		},
+		{
+			Title: "End time",
+			Value: "2022-05-15 02:00 CEST",
+			Short: true,
+		},
+		{
+			Title: "Affected entities",
+			Value: "i-0123456789abcdef0, i-0123456789abcdef1",
+			Short: false,
+		},
+		{
+			Title: "Description",
+			Value: "One or more of your EC2 instances is scheduled for a reboot.",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "AWS Health scheduledChange: EC2 AWS_EC2_INSTANCE_REBOOT_MAINTENANCE_SCHEDULED (upcoming)", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForIssueHealthEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(issueHealthEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, slackAttachmentField{Title: "Start time", Value: "2022-05-03 07:00 UTC", Short: true}, msg.Fields[4])
+}
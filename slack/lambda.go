@@ -0,0 +1,128 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
+
+const (
+	// maxLambdaStackTraceLines is the number of stack trace lines shown
+	maxLambdaStackTraceLines = 5
+	// maxLambdaRequestPayload caps the length of the request payload snippet
+	maxLambdaRequestPayload = 500
+)
+
+func isLambdaDestination(message *gabs.Container) bool {
+	return message.Exists("requestContext", "functionArn") && message.Exists("requestContext", "condition")
+}
+
+// lambdaDestination parses the record Lambda sends to asynchronous invocation
+// destinations
+func lambdaDestination(message *gabs.Container) *Notification {
+	functionArn, _ := message.Path("requestContext.functionArn").Data().(string)
+	condition, _ := message.Path("requestContext.condition").Data().(string)
+	requestID, _ := message.Path("requestContext.requestId").Data().(string)
+	invokeCount, _ := message.Path("requestContext.approximateInvokeCount").Data().(float64)
+	functionError, _ := message.Path("responseContext.functionError").Data().(string)
+	errorType, _ := message.Path("responsePayload.errorType").Data().(string)
+	errorMessage, _ := message.Path("responsePayload.errorMessage").Data().(string)
+	timestamp, _ := message.Path("timestamp").Data().(string)
+
+	// arn:aws:lambda:<region>:<account>:function:<name>[:<qualifier>]
+	var region, account, function string
+	if parts := strings.Split(functionArn, ":"); len(parts) >= 7 {
+		region, account, function = parts[3], parts[4], parts[6]
+	}
+
+	fields := []Field{
+		{
+			Title: "Function",
+			Value: function,
+			Short: true,
+		},
+		{
+			Title: "Condition",
+			Value: condition,
+			Short: true,
+		},
+		{
+			Title: "Attempts",
+			Value: fmt.Sprintf("%.0f", invokeCount),
+			Short: true,
+		},
+	}
+
+	if errorType == "" {
+		errorType = functionError
+	}
+	if errorType != "" {
+		fields = append(fields, Field{
+			Title: "Error type",
+			Value: errorType,
+			Short: true,
+		})
+	}
+
+	if errorMessage != "" {
+		fields = append(fields, Field{
+			Title: "Error",
+			Value: errorMessage,
+			Short: false,
+		})
+	}
+
+	var stackTrace []string
+	frames, _ := message.Path("responsePayload.stackTrace").Children()
+	for _, frame := range frames {
+		if len(stackTrace) == maxLambdaStackTraceLines {
+			break
+		}
+		if line, ok := frame.Data().(string); ok {
+			stackTrace = append(stackTrace, strings.TrimSpace(line))
+		}
+	}
+	if len(stackTrace) > 0 {
+		fields = append(fields, Field{
+			Title: "Stack trace",
+			Value: codeBlock(strings.Join(stackTrace, "\n")),
+			Short: false,
+		})
+	}
+
+	if message.Exists("requestPayload") {
+		fields = append(fields, Field{
+			Title: "Request payload",
+			Value: codeBlock(truncate(message.Path("requestPayload").String(), maxLambdaRequestPayload)),
+			Short: false,
+		})
+	}
+
+	severity := SeverityCritical
+	if condition == "Success" {
+		severity = SeverityOK
+	}
+
+	parsedTime, _ := time.Parse(time.RFC3339, timestamp)
+
+	return &Notification{
+		Title:     fmt.Sprintf("Asynchronous invocation of %s: %s", function, condition),
+		Severity:  severity,
+		State:     condition,
+		Source:    "aws.lambda",
+		Account:   account,
+		Region:    region,
+		Resource:  functionArn,
+		Fields:    fields,
+		Timestamp: parsedTime,
+		DedupKey:  requestID,
+		Links: []Link{
+			{
+				Title: "Function",
+				URL:   fmt.Sprintf("https://%s.console.aws.amazon.com/lambda/home?region=%s#/functions/%s", region, region, function),
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var lambdaFailureDestinationEvent = testSNSEvent("{\"version\":\"1.0\",\"timestamp\":\"2022-05-03T10:16:05.568Z\",\"requestContext\":{\"requestId\":\"e4b46cbf-b738-xmpl-8880-a18cdf61200e\",\"functionArn\":\"arn:aws:lambda:eu-west-1:123456789000:function:order-processor:$LATEST\",\"condition\":\"RetriesExhausted\",\"approximateInvokeCount\":3},\"requestPayload\":{\"orderId\":\"1234\"},\"responseContext\":{\"statusCode\":200,\"executedVersion\":\"$LATEST\",\"functionError\":\"Unhandled\"},\"responsePayload\":{\"errorMessage\":\"order not found\",\"errorType\":\"NotFoundError\",\"stackTrace\":[\"    at getOrder (/var/task/index.js:10:11)\",\"    at process (/var/task/index.js:20:5)\",\"    at handler (/var/task/index.js:30:3)\",\"    at Runtime.handleOnce (/var/runtime/Runtime.js:66:25)\",\"    at Runtime.next (/var/runtime/Runtime.js:70:9)\",\"    at Runtime.run (/var/runtime/Runtime.js:75:9)\"]}}")
+
+func TestCreateSlackMessageAttachmentForLambdaFailureDestinationEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(lambdaFailureDestinationEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Function",
+			Value: "order-processor",
+			Short: true,
+		},
+		{
+			Title: "Condition",
+			Value: "RetriesExhausted",
+			Short: true,
+		},
+		{
+			Title: "Attempts",
+			Value: "3",
+			Short: true,
+		},
+		{
+			Title: "Error type",
+			Value: "NotFoundError",
+			Short: true,
+		},
+		{
+			Title: "Error",
+			Value: "order not found",
+			Short: false,
+		},
+		{
+			Title: "Stack trace",
+			Value: "```at getOrder (/var/task/index.js:10:11)\nat process (/var/task/index.js:20:5)\nat handler (/var/task/index.js:30:3)\nat Runtime.handleOnce (/var/runtime/Runtime.js:66:25)\nat Runtime.next (/var/runtime/Runtime.js:70:9)```",
+			Short: false,
+		},
+		{
+			Title: "Request payload",
+			Value: "```{\"orderId\":\"1234\"}```",
+			Short: false,
+		},
+		{
+			Title: "Function",
+			Value: "<https://eu-west-1.console.aws.amazon.com/lambda/home?region=eu-west-1#/functions/order-processor|Function>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Asynchronous invocation of order-processor: RetriesExhausted", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
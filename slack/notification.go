@@ -0,0 +1,151 @@
+package slack
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
+
+// Severity orders notifications from informational to critical
+type Severity int
+
+// Severities a notification can have
+const (
+	SeverityOK Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// Field is a titled value shown with a notification
+type Field struct {
+	Title string
+	Value string
+	Short bool
+}
+
+// Link points to a page with more information about a notification
+type Link struct {
+	Title string
+	URL   string
+}
+
+// Notification is the normalized form of an incoming event. Parsers turn
+// payloads into notifications and renderers turn notifications into the
+// payload of an output such as Slack attachments.
+type Notification struct {
+	Title     string
+	Severity  Severity
+	State     string
+	Source    string
+	Account   string
+	Region    string
+	Resource  string
+	Fields    []Field
+	Links     []Link
+	Timestamp time.Time
+	DedupKey  string
+}
+
+// eventBridgeNotification fills in the fields every EventBridge event carries
+// in its envelope
+func eventBridgeNotification(message *gabs.Container) Notification {
+	source, _ := message.Path("source").Data().(string)
+	account, _ := message.Path("account").Data().(string)
+	region, _ := message.Path("region").Data().(string)
+	id, _ := message.Path("id").Data().(string)
+	eventTime, _ := message.Path("time").Data().(string)
+
+	var resource string
+	if resources, _ := message.Path("resources").Children(); len(resources) > 0 {
+		resource, _ = resources[0].Data().(string)
+	}
+
+	timestamp, _ := time.Parse(time.RFC3339, eventTime)
+
+	return Notification{
+		Source:    source,
+		Account:   account,
+		Region:    region,
+		Resource:  resource,
+		Timestamp: timestamp,
+		DedupKey:  id,
+	}
+}
+
+// alertNotification builds the alarm style notification shared by CloudWatch
+// alarms and the alerting tools rendered like them
+func alertNotification(name, status, reason, region string) Notification {
+	title := status + ": " + name
+	if region != "" {
+		title += " in " + region
+	}
+
+	return Notification{
+		Title:    title,
+		Severity: mapSeverity(status),
+		State:    status,
+		Region:   region,
+		Resource: name,
+		Fields: []Field{
+			{
+				Title: "Alarm",
+				Value: name,
+				Short: true,
+			},
+			{
+				Title: "Status",
+				Value: status,
+				Short: true,
+			},
+			{
+				Title: "Reason",
+				Value: reason,
+				Short: false,
+			},
+		},
+	}
+}
+
+func mapSeverity(status string) Severity {
+	switch status {
+	case "STOPPED", "ALARM", "FIRING":
+		return SeverityCritical
+	case "INSUFFICIENT_DATA":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// formatTime renders t in the timezone named by the TIMEZONE environment
+// variable, defaulting to UTC
+func formatTime(t time.Time) string {
+	location := time.UTC
+	if name := os.Getenv("TIMEZONE"); name != "" {
+		loaded, err := time.LoadLocation(name)
+		if err != nil {
+			log.Println("Unknown TIMEZONE", name, err)
+		} else {
+			location = loaded
+		}
+	}
+
+	return t.In(location).Format("2006-01-02 15:04 MST")
+}
+
+// truncate shortens s to at most max characters, marking the cut with an ellipsis
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	return string(runes[:max-1]) + "…"
+}
+
+// codeBlock renders s as a Slack code block
+func codeBlock(s string) string {
+	return "```" + s + "```"
+}
@@ -0,0 +1,37 @@
+package slack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateNotificationForEcsTaskEvent(t *testing.T) {
+	notification := CreateNotification(stoppedEcsTaskEvent)
+
+	assert.Equal(t, "Task service:2 in service cluster changed state: STOPPED", notification.Title)
+	assert.Equal(t, SeverityCritical, notification.Severity)
+	assert.Equal(t, "STOPPED", notification.State)
+	assert.Equal(t, "aws.ecs", notification.Source)
+	assert.Equal(t, "123456789000", notification.Account)
+	assert.Equal(t, "eu-west-1", notification.Region)
+	assert.Equal(t, "arn:aws:ecs:eu-west-1:123456789000:task/service/12345", notification.Resource)
+	assert.Equal(t, time.Date(2022, 5, 3, 7, 30, 7, 0, time.UTC), notification.Timestamp)
+	assert.Equal(t, "09824691-294a-8f91-0bd5-01e13c5a3c8d", notification.DedupKey)
+}
+
+func TestCreateNotificationForAlarm(t *testing.T) {
+	notification := CreateNotification(testAlarmEvent)
+
+	assert.Equal(t, SeverityOK, notification.Severity)
+	assert.Equal(t, "aws.cloudwatch", notification.Source)
+	assert.Equal(t, "123456789012", notification.Account)
+	assert.Equal(t, "sns-cloudwatch/2015-11-09T21:19:43.454+0000", notification.DedupKey)
+}
+
+func TestCreateNotificationForUnknownMessage(t *testing.T) {
+	assert.Nil(t, CreateNotification(testSNSEvent("{\"foo\":\"bar\"}")))
+	assert.Nil(t, CreateNotification(testSNSEvent("not json")))
+	assert.Equal(t, "", CreateSlackMessageAttachment(testSNSEvent("not json")))
+}
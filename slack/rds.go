@@ -0,0 +1,157 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// rdsEventCategories maps RDS event IDs to their category for SNS event
+// subscription messages, which do not carry the category themselves
+var rdsEventCategories = map[string]string{
+	"RDS-EVENT-0004": "availability",
+	"RDS-EVENT-0006": "availability",
+	"RDS-EVENT-0007": "low storage",
+	"RDS-EVENT-0013": "failover",
+	"RDS-EVENT-0015": "failover",
+	"RDS-EVENT-0026": "maintenance",
+	"RDS-EVENT-0027": "maintenance",
+	"RDS-EVENT-0031": "failure",
+	"RDS-EVENT-0034": "failover",
+	"RDS-EVENT-0035": "failure",
+	"RDS-EVENT-0036": "failure",
+	"RDS-EVENT-0047": "maintenance",
+	"RDS-EVENT-0048": "maintenance",
+	"RDS-EVENT-0049": "failover",
+	"RDS-EVENT-0050": "failover",
+	"RDS-EVENT-0058": "failure",
+	"RDS-EVENT-0065": "failover",
+	"RDS-EVENT-0069": "failover",
+	"RDS-EVENT-0070": "failover",
+	"RDS-EVENT-0071": "failover",
+	"RDS-EVENT-0072": "failover",
+	"RDS-EVENT-0073": "failover",
+	"RDS-EVENT-0089": "low storage",
+}
+
+// rdsSeverity maps event categories to a severity. Reboots and shutdowns are
+// reported by RDS in the availability category.
+func rdsSeverity(categories []string) Severity {
+	severity := SeverityOK
+	for _, category := range categories {
+		switch category {
+		case "failover", "failure", "low storage":
+			return SeverityCritical
+		case "availability", "maintenance", "recovery":
+			severity = SeverityWarning
+		}
+	}
+
+	return severity
+}
+
+func rdsNotification(notification Notification, sourceID, sourceType, eventID, eventTime, eventMessage string, categories []string) *Notification {
+	notification.Title = fmt.Sprintf("RDS %s on %s: %s", eventID, sourceID, eventMessage)
+	notification.Severity = rdsSeverity(categories)
+	notification.State = eventID
+	notification.Fields = []Field{
+		{
+			Title: "Source ID",
+			Value: sourceID,
+			Short: true,
+		},
+		{
+			Title: "Source type",
+			Value: sourceType,
+			Short: true,
+		},
+		{
+			Title: "Event ID",
+			Value: eventID,
+			Short: true,
+		},
+		{
+			Title: "Category",
+			Value: strings.Join(categories, ", "),
+			Short: true,
+		},
+		{
+			Title: "Event time",
+			Value: eventTime,
+			Short: true,
+		},
+		{
+			Title: "Message",
+			Value: eventMessage,
+			Short: false,
+		},
+	}
+
+	return &notification
+}
+
+// rdsEvent parses the EventBridge RDS DB instance and cluster events
+func rdsEvent(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	sourceID, _ := detail.Path("SourceIdentifier").Data().(string)
+	sourceType, _ := detail.Path("SourceType").Data().(string)
+	sourceArn, _ := detail.Path("SourceArn").Data().(string)
+	eventID, _ := detail.Path("EventID").Data().(string)
+	date, _ := detail.Path("Date").Data().(string)
+	eventMessage, _ := detail.Path("Message").Data().(string)
+
+	var categories []string
+	eventCategories, _ := detail.Path("EventCategories").Children()
+	for _, category := range eventCategories {
+		if value, _ := category.Data().(string); value != "" {
+			categories = append(categories, value)
+		}
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Resource = sourceArn
+
+	return rdsNotification(notification, sourceID, sourceType, eventID, date, eventMessage, categories)
+}
+
+func isRdsSubscriptionEvent(message *gabs.Container) bool {
+	return message.Exists("Event Source") && message.Exists("Event ID")
+}
+
+// rdsSubscriptionEvent parses messages sent by RDS event subscriptions
+func rdsSubscriptionEvent(message *gabs.Container) *Notification {
+	sourceID, _ := message.Path("Source ID").Data().(string)
+	sourceType, _ := message.Path("Event Source").Data().(string)
+	sourceArn, _ := message.Path("Source ARN").Data().(string)
+	eventTime, _ := message.Path("Event Time").Data().(string)
+	eventMessage, _ := message.Path("Event Message").Data().(string)
+	identifierLink, _ := message.Path("Identifier Link").Data().(string)
+
+	// The event ID is a link to the documentation ending in #RDS-EVENT-0000
+	eventID, _ := message.Path("Event ID").Data().(string)
+	eventID = eventID[strings.LastIndex(eventID, "#")+1:]
+
+	var categories []string
+	if category, ok := rdsEventCategories[eventID]; ok {
+		categories = append(categories, category)
+	}
+
+	notification := Notification{
+		Source:   "aws.rds",
+		Resource: sourceArn,
+		DedupKey: sourceID + "/" + eventID + "/" + eventTime,
+	}
+
+	if identifierLink != "" {
+		notification.Links = []Link{
+			{
+				Title: "Console",
+				URL:   identifierLink,
+			},
+		}
+	}
+
+	return rdsNotification(notification, sourceID, sourceType, eventID, eventTime, eventMessage, categories)
+}
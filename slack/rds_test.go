@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rebootRdsSubscriptionEvent = testSNSEvent("{\"Event Source\":\"db-instance\",\"Event Time\":\"2022-05-03 07:29:20.123\",\"Identifier Link\":\"https://console.aws.amazon.com/rds/home?region=eu-west-1#dbinstance:id=orders\",\"Source ID\":\"orders\",\"Source ARN\":\"arn:aws:rds:eu-west-1:123456789000:db:orders\",\"Event ID\":\"http://docs.amazonwebservices.com/AmazonRDS/latest/UserGuide/USER_Events.html#RDS-EVENT-0006\",\"Event Message\":\"DB instance restarted\"}")
+
+var failoverRdsClusterEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"844e2571-85d4-695f-b930-0153b71dcb42\",\"detail-type\":\"RDS DB Cluster Event\",\"source\":\"aws.rds\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:rds:eu-west-1:123456789000:cluster:orders\"],\"detail\":{\"EventCategories\":[\"failover\"],\"SourceType\":\"CLUSTER\",\"SourceArn\":\"arn:aws:rds:eu-west-1:123456789000:cluster:orders\",\"Date\":\"2022-05-03T07:29:20.123Z\",\"Message\":\"Completed failover to DB instance: orders-2\",\"SourceIdentifier\":\"orders\",\"EventID\":\"RDS-EVENT-0071\"}}")
+
+func TestCreateSlackMessageAttachmentForRebootRdsSubscriptionEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(rebootRdsSubscriptionEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Source ID",
+			Value: "orders",
+			Short: true,
+		},
+		{
+			Title: "Source type",
+			Value: "db-instance",
+			Short: true,
+		},
+		{
+			Title: "Event ID",
+			Value: "RDS-EVENT-0006",
+			Short: true,
+		},
+		{
+			Title: "Category",
+			Value: "availability",
+			Short: true,
+		},
+		{
+			Title: "Event time",
+			Value: "2022-05-03 07:29:20.123",
+			Short: true,
+		},
+		{
+			Title: "Message",
+			Value: "DB instance restarted",
+			Short: false,
+		},
+		{
+			Title: "Console",
+			Value: "<https://console.aws.amazon.com/rds/home?region=eu-west-1#dbinstance:id=orders|Console>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "RDS RDS-EVENT-0006 on orders: DB instance restarted", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForFailoverRdsClusterEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failoverRdsClusterEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "RDS RDS-EVENT-0071 on orders: Completed failover to DB instance: orders-2", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Category", Value: "failover", Short: true}, msg.Fields[3])
+}
@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// maxSecurityHubResources caps the resources listed per collapsed finding
+const maxSecurityHubResources = 5
+
+type securityHubFinding struct {
+	title      string
+	severity   string
+	compliance string
+	product    string
+	resources  []string
+	count      int
+}
+
+func securityHubSeverity(label string) Severity {
+	switch label {
+	case "CRITICAL", "HIGH":
+		return SeverityCritical
+	case "MEDIUM":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+func (f securityHubFinding) field() Field {
+	title := f.title
+	if f.count > 1 {
+		title = fmt.Sprintf("%s (x%d)", f.title, f.count)
+	}
+
+	lines := []string{fmt.Sprintf("Severity: %s", f.severity)}
+	if f.compliance != "" {
+		lines = append(lines, fmt.Sprintf("Compliance: %s", f.compliance))
+	}
+	lines = append(lines, fmt.Sprintf("Product: %s", f.product))
+
+	resources := f.resources
+	if len(resources) > maxSecurityHubResources {
+		resources = append(resources[:maxSecurityHubResources:maxSecurityHubResources],
+			fmt.Sprintf("and %d more", len(f.resources)-maxSecurityHubResources))
+	}
+	if len(resources) > 0 {
+		lines = append(lines, fmt.Sprintf("Resources: %s", strings.Join(resources, ", ")))
+	}
+
+	return Field{
+		Title: title,
+		Value: strings.Join(lines, "\n"),
+		Short: false,
+	}
+}
+
+// securityHubFindings parses a batch of imported findings, collapsing
+// identical findings into one field with a count
+func securityHubFindings(message *gabs.Container) *Notification {
+	findings, _ := message.Path("detail.findings").Children()
+
+	var collapsed []*securityHubFinding
+	byKey := map[string]*securityHubFinding{}
+	severity := SeverityOK
+
+	for _, finding := range findings {
+		title, _ := finding.Path("Title").Data().(string)
+		label, _ := finding.Path("Severity.Label").Data().(string)
+		compliance, _ := finding.Path("Compliance.Status").Data().(string)
+		product, _ := finding.Path("ProductName").Data().(string)
+
+		key := strings.Join([]string{title, label, compliance, product}, "\x00")
+		entry, ok := byKey[key]
+		if !ok {
+			entry = &securityHubFinding{
+				title:      title,
+				severity:   label,
+				compliance: compliance,
+				product:    product,
+			}
+			byKey[key] = entry
+			collapsed = append(collapsed, entry)
+		}
+		entry.count++
+
+		resources, _ := finding.Path("Resources").Children()
+		for _, resource := range resources {
+			if id, _ := resource.Path("Id").Data().(string); id != "" {
+				entry.resources = append(entry.resources, id)
+			}
+		}
+
+		if s := securityHubSeverity(label); s > severity {
+			severity = s
+		}
+	}
+
+	if len(collapsed) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, len(collapsed))
+	for _, finding := range collapsed {
+		fields = append(fields, finding.field())
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Security Hub imported %d findings", len(findings))
+	if len(findings) == 1 {
+		notification.Title = "Security Hub finding: " + collapsed[0].title
+	}
+	notification.Severity = severity
+	notification.Fields = fields
+
+	return &notification
+}
@@ -0,0 +1,47 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var securityHubFindingsEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"8e5622f9-d81c-4d81-612a-9319e7ee2506\",\"detail-type\":\"Security Hub Findings - Imported\",\"source\":\"aws.securityhub\",\"account\":\"123456789000\",\"time\":\"2022-05-03T07:29:20Z\",\"region\":\"eu-west-1\",\"resources\":[],\"detail\":{\"findings\":[{\"SchemaVersion\":\"2018-10-08\",\"Id\":\"finding-1\",\"ProductName\":\"Security Hub\",\"AwsAccountId\":\"123456789000\",\"Title\":\"S3 buckets should prohibit public read access\",\"Severity\":{\"Label\":\"CRITICAL\",\"Normalized\":90},\"Compliance\":{\"Status\":\"FAILED\"},\"Resources\":[{\"Type\":\"AwsS3Bucket\",\"Id\":\"arn:aws:s3:::public-bucket\"}]},{\"SchemaVersion\":\"2018-10-08\",\"Id\":\"finding-2\",\"ProductName\":\"Security Hub\",\"AwsAccountId\":\"123456789000\",\"Title\":\"S3 buckets should prohibit public read access\",\"Severity\":{\"Label\":\"CRITICAL\",\"Normalized\":90},\"Compliance\":{\"Status\":\"FAILED\"},\"Resources\":[{\"Type\":\"AwsS3Bucket\",\"Id\":\"arn:aws:s3:::other-bucket\"}]},{\"SchemaVersion\":\"2018-10-08\",\"Id\":\"finding-3\",\"ProductName\":\"Inspector\",\"AwsAccountId\":\"123456789000\",\"Title\":\"CVE-2022-0001 - openssl\",\"Severity\":{\"Label\":\"MEDIUM\",\"Normalized\":40},\"Resources\":[{\"Type\":\"AwsEc2Instance\",\"Id\":\"arn:aws:ec2:eu-west-1:123456789000:instance/i-0123456789abcdef0\"}]}]}}")
+
+func TestCreateSlackMessageAttachmentForSecurityHubFindingsEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(securityHubFindingsEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "S3 buckets should prohibit public read access (x2)",
+			Value: "Severity: CRITICAL\nCompliance: FAILED\nProduct: Security Hub\nResources: arn:aws:s3:::public-bucket, arn:aws:s3:::other-bucket",
+			Short: false,
+		},
+		{
+			Title: "CVE-2022-0001 - openssl",
+			Value: "Severity: MEDIUM\nProduct: Inspector\nResources: arn:aws:ec2:eu-west-1:123456789000:instance/i-0123456789abcdef0",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Security Hub imported 3 findings", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestSecurityHubFindingFieldTruncatesResources(t *testing.T) {
+	field := securityHubFinding{
+		title:     "IAM root user access key should not exist",
+		severity:  "CRITICAL",
+		product:   "Security Hub",
+		resources: []string{"a", "b", "c", "d", "e", "f", "g"},
+		count:     7,
+	}.field()
+
+	assert.Equal(t, "IAM root user access key should not exist (x7)", field.Title)
+	assert.Equal(t, "Severity: CRITICAL\nProduct: Security Hub\nResources: a, b, c, d, e, and 2 more", field.Value)
+}
@@ -0,0 +1,187 @@
+package slack
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// maxSesRecipients is the number of recipients listed before the rest are counted
+const maxSesRecipients = 10
+
+var emailAddress = regexp.MustCompile(`[^\s<>()\[\]"';:,]+@[A-Za-z0-9.-]+`)
+
+func isSesNotification(message *gabs.Container) bool {
+	return message.Exists("mail", "messageId") && (message.Exists("notificationType") || message.Exists("eventType"))
+}
+
+// maskEmail hides the local part of an address when SES_MASK_EMAILS is set,
+// keeping the first character and the domain
+func maskEmail(address string) string {
+	if os.Getenv("SES_MASK_EMAILS") != "true" {
+		return address
+	}
+
+	at := strings.LastIndex(address, "@")
+	if at < 1 {
+		return "***"
+	}
+
+	return address[:1] + "***" + address[at:]
+}
+
+// maskEmails masks every address found in free text such as SMTP diagnostics
+func maskEmails(text string) string {
+	return emailAddress.ReplaceAllStringFunc(text, maskEmail)
+}
+
+// sesRecipients lists masked recipients, counting those beyond maxSesRecipients
+func sesRecipients(addresses []string) string {
+	masked := make([]string, 0, maxSesRecipients)
+	for i, address := range addresses {
+		if i == maxSesRecipients {
+			masked = append(masked, fmt.Sprintf("and %d more", len(addresses)-maxSesRecipients))
+			break
+		}
+		masked = append(masked, maskEmail(address))
+	}
+
+	return strings.Join(masked, ", ")
+}
+
+// sesAddresses collects recipient addresses, either plain strings or objects
+// with an emailAddress
+func sesAddresses(recipients *gabs.Container) []string {
+	children, _ := recipients.Children()
+
+	var addresses []string
+	for _, recipient := range children {
+		if address, ok := recipient.Data().(string); ok {
+			addresses = append(addresses, address)
+		} else if address, ok := recipient.Path("emailAddress").Data().(string); ok {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+// sesNotification parses SES bounce, complaint and delivery notifications and
+// the matching event publishing records
+func sesNotification(message *gabs.Container) *Notification {
+	notificationType, _ := message.Path("notificationType").Data().(string)
+	if notificationType == "" {
+		notificationType, _ = message.Path("eventType").Data().(string)
+	}
+
+	source, _ := message.Path("mail.source").Data().(string)
+	sourceArn, _ := message.Path("mail.sourceArn").Data().(string)
+	messageID, _ := message.Path("mail.messageId").Data().(string)
+	subject, _ := message.Path("mail.commonHeaders.subject").Data().(string)
+
+	var severity Severity
+	var kind string
+	var addresses []string
+	var details []Field
+
+	switch notificationType {
+	case "Bounce":
+		bounceType, _ := message.Path("bounce.bounceType").Data().(string)
+		bounceSubType, _ := message.Path("bounce.bounceSubType").Data().(string)
+		addresses = sesAddresses(message.Path("bounce.bouncedRecipients"))
+
+		severity = SeverityWarning
+		if bounceType == "Permanent" {
+			severity = SeverityCritical
+		}
+		kind = strings.TrimSpace(bounceType + " bounce")
+		details = append(details, Field{
+			Title: "Bounce type",
+			Value: strings.Trim(bounceType+"/"+bounceSubType, "/"),
+			Short: true,
+		})
+
+		recipients, _ := message.Path("bounce.bouncedRecipients").Children()
+		if len(recipients) > 0 {
+			if diagnostic, _ := recipients[0].Path("diagnosticCode").Data().(string); diagnostic != "" {
+				details = append(details, Field{
+					Title: "Diagnostic",
+					Value: maskEmails(diagnostic),
+					Short: false,
+				})
+			}
+		}
+	case "Complaint":
+		feedbackType, _ := message.Path("complaint.complaintFeedbackType").Data().(string)
+		addresses = sesAddresses(message.Path("complaint.complainedRecipients"))
+
+		severity = SeverityWarning
+		kind = "Complaint"
+		if feedbackType != "" {
+			details = append(details, Field{
+				Title: "Feedback type",
+				Value: feedbackType,
+				Short: true,
+			})
+		}
+	case "Delivery":
+		addresses = sesAddresses(message.Path("delivery.recipients"))
+
+		severity = SeverityOK
+		kind = "Delivery"
+	default:
+		return nil
+	}
+
+	fields := []Field{
+		{
+			Title: "Source",
+			Value: source,
+			Short: true,
+		},
+		{
+			Title: "Recipients",
+			Value: fmt.Sprintf("%d", len(addresses)),
+			Short: true,
+		},
+	}
+	fields = append(fields, details...)
+	fields = append(fields, Field{
+		Title: "Addresses",
+		Value: sesRecipients(addresses),
+		Short: false,
+	})
+
+	if subject != "" {
+		fields = append(fields, Field{
+			Title: "Subject",
+			Value: subject,
+			Short: false,
+		})
+	}
+
+	var region, account string
+	if parts := strings.Split(sourceArn, ":"); len(parts) >= 5 {
+		region, account = parts[3], parts[4]
+	}
+
+	title := fmt.Sprintf("SES %s from %s to %d recipient", kind, source, len(addresses))
+	if len(addresses) != 1 {
+		title += "s"
+	}
+
+	return &Notification{
+		Title:    title,
+		Severity: severity,
+		State:    notificationType,
+		Source:   "aws.ses",
+		Account:  account,
+		Region:   region,
+		Resource: source,
+		Fields:   fields,
+		DedupKey: messageID,
+	}
+}
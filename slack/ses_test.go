@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sesBounceEvent = testSNSEvent("{\"notificationType\":\"Bounce\",\"bounce\":{\"feedbackId\":\"0102017f-a53f-4b8f-9c5e-5b6e0a2a1d3c-000000\",\"bounceType\":\"Permanent\",\"bounceSubType\":\"General\",\"bouncedRecipients\":[{\"emailAddress\":\"jane@example.com\",\"action\":\"failed\",\"status\":\"5.1.1\",\"diagnosticCode\":\"smtp; 550 5.1.1 user unknown\"},{\"emailAddress\":\"john@example.org\",\"action\":\"failed\",\"status\":\"5.1.1\",\"diagnosticCode\":\"smtp; 550 5.1.1 user unknown\"}],\"timestamp\":\"2022-05-03T11:00:01.000Z\",\"reportingMTA\":\"dsn; a1-2.smtp-out.eu-west-1.amazonses.com\"},\"mail\":{\"timestamp\":\"2022-05-03T11:00:00.000Z\",\"source\":\"no-reply@shop.example.net\",\"sourceArn\":\"arn:aws:ses:eu-west-1:123456789000:identity/shop.example.net\",\"sendingAccountId\":\"123456789000\",\"messageId\":\"0102017f-a53f-4b8f-9c5e-5b6e0a2a1d3c-000000\",\"destination\":[\"jane@example.com\",\"john@example.org\"],\"commonHeaders\":{\"from\":[\"no-reply@shop.example.net\"],\"to\":[\"jane@example.com\",\"john@example.org\"],\"subject\":\"Your order\"}}}")
+
+var sesComplaintEvent = testSNSEvent("{\"notificationType\":\"Complaint\",\"complaint\":{\"complainedRecipients\":[{\"emailAddress\":\"jane@example.com\"}],\"timestamp\":\"2022-05-03T11:05:00.000Z\",\"feedbackId\":\"0102017f-b53f-4b8f-9c5e-5b6e0a2a1d3c-000000\",\"complaintFeedbackType\":\"abuse\"},\"mail\":{\"timestamp\":\"2022-05-03T11:00:00.000Z\",\"source\":\"news@shop.example.net\",\"sourceArn\":\"arn:aws:ses:eu-west-1:123456789000:identity/shop.example.net\",\"messageId\":\"0102017f-c53f-4b8f-9c5e-5b6e0a2a1d3c-000000\",\"destination\":[\"jane@example.com\"]}}")
+
+func TestCreateSlackMessageAttachmentForSesBounceEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(sesBounceEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Source",
+			Value: "no-reply@shop.example.net",
+			Short: true,
+		},
+		{
+			Title: "Recipients",
+			Value: "2",
+			Short: true,
+		},
+		{
+			Title: "Bounce type",
+			Value: "Permanent/General",
+			Short: true,
+		},
+		{
+			Title: "Diagnostic",
+			Value: "smtp; 550 5.1.1 user unknown",
+			Short: false,
+		},
+		{
+			Title: "Addresses",
+			Value: "jane@example.com, john@example.org",
+			Short: false,
+		},
+		{
+			Title: "Subject",
+			Value: "Your order",
+			Short: false,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "SES Permanent bounce from no-reply@shop.example.net to 2 recipients", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+var maskedSesBounceEvent = testSNSEvent("{\"notificationType\":\"Bounce\",\"bounce\":{\"bounceType\":\"Permanent\",\"bounceSubType\":\"General\",\"bouncedRecipients\":[{\"emailAddress\":\"jane@example.com\",\"action\":\"failed\",\"status\":\"5.1.1\",\"diagnosticCode\":\"smtp; 550 <jane@example.com> unknown, see postmaster@example.com\"}],\"timestamp\":\"2022-05-03T11:00:01.000Z\"},\"mail\":{\"timestamp\":\"2022-05-03T11:00:00.000Z\",\"source\":\"no-reply@shop.example.net\",\"sourceArn\":\"arn:aws:ses:eu-west-1:123456789000:identity/shop.example.net\",\"messageId\":\"0102017f-d53f-4b8f-9c5e-5b6e0a2a1d3c-000000\",\"destination\":[\"jane@example.com\"]}}")
+
+func TestCreateSlackMessageAttachmentForMaskedSesBounceEvent(t *testing.T) {
+	t.Setenv("SES_MASK_EMAILS", "true")
+
+	slackMessageAttachments := CreateSlackMessageAttachment(maskedSesBounceEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, slackAttachmentField{Title: "Diagnostic", Value: "smtp; 550 <j***@example.com> unknown, see p***@example.com", Short: false}, msg.Fields[3])
+	assert.Equal(t, slackAttachmentField{Title: "Addresses", Value: "j***@example.com", Short: false}, msg.Fields[4])
+}
+
+func TestCreateSlackMessageAttachmentForSesComplaintEvent(t *testing.T) {
+	t.Setenv("SES_MASK_EMAILS", "true")
+
+	slackMessageAttachments := CreateSlackMessageAttachment(sesComplaintEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "warning", msg.Color)
+	assert.Equal(t, "SES Complaint from news@shop.example.net to 1 recipient", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Feedback type", Value: "abuse", Short: true}, msg.Fields[2])
+	assert.Equal(t, slackAttachmentField{Title: "Addresses", Value: "j***@example.com", Short: false}, msg.Fields[3])
+}
+
+func TestSesRecipients(t *testing.T) {
+	addresses := make([]string, 12)
+	for i := range addresses {
+		addresses[i] = "user@example.com"
+	}
+
+	assert.Equal(t, "user@example.com, user@example.com, user@example.com, user@example.com, user@example.com, user@example.com, user@example.com, user@example.com, user@example.com, user@example.com, and 2 more", sesRecipients(addresses))
+}
@@ -26,12 +26,12 @@ type MessageAttachments struct {
 	Fields   []slackAttachmentField `json:"fields,omitempty"`
 }
 
-func mapColor(status string) string {
+func mapColor(severity Severity) string {
 	var colorCode string
-	switch status {
-	case "STOPPED", "ALARM":
+	switch severity {
+	case SeverityCritical:
 		colorCode = "danger"
-	case "INSUFFICIENT_DATA":
+	case SeverityWarning:
 		colorCode = "warning"
 	default:
 		colorCode = "good"
@@ -40,7 +40,7 @@ func mapColor(status string) string {
 	return colorCode
 }
 
-func ecsTaskStateChange(message *gabs.Container) string {
+func ecsTaskStateChange(message *gabs.Container) *Notification {
 	detail := message.Path("detail")
 
 	clusterArn, _ := detail.Path("clusterArn").Data().(string)
@@ -54,7 +54,7 @@ func ecsTaskStateChange(message *gabs.Container) string {
 	taskName := taskArn[strings.LastIndex(taskArn, "/")+1:]
 	taskDefinitionName := taskDefinitionArn[strings.LastIndex(taskDefinitionArn, "/")+1:]
 
-	slackAttachmentFields := []slackAttachmentField{
+	fields := []Field{
 		{
 			Title: "Last status",
 			Value: lastStatus,
@@ -83,70 +83,63 @@ func ecsTaskStateChange(message *gabs.Container) string {
 	}
 
 	if stoppedReason != "" {
-		slackAttachmentFields = append(slackAttachmentFields, slackAttachmentField{
+		fields = append(fields, Field{
 			Title: "Stopped reason",
 			Value: stoppedReason,
 			Short: true,
 		})
 	}
 
-	pretext := fmt.Sprintf("Task %s in %s cluster is changing state: %s -> %s", taskDefinitionName, clusterName, lastStatus, desiredStatus)
+	title := fmt.Sprintf("Task %s in %s cluster is changing state: %s -> %s", taskDefinitionName, clusterName, lastStatus, desiredStatus)
 
 	if lastStatus == desiredStatus {
-		pretext = fmt.Sprintf("Task %s in %s cluster changed state: %s", taskDefinitionName, clusterName, lastStatus)
-	}
-
-	username := os.Getenv("USERNAME")
-	if username == "" {
-		username = "AWS-bot"
-	}
-
-	icon := os.Getenv("ICON")
-	if icon == "" {
-		icon = ":loudspeaker:"
+		title = fmt.Sprintf("Task %s in %s cluster changed state: %s", taskDefinitionName, clusterName, lastStatus)
 	}
 
-	slackMessageAttachments := MessageAttachments{
-		Color:    mapColor(desiredStatus),
-		Pretext:  pretext,
-		Username: username,
-		Icon:     icon,
-		Fields:   slackAttachmentFields,
-	}
-
-	resp, err := json.Marshal(slackMessageAttachments)
-	if err != nil {
-		log.Fatal("Error building Slack attachments", err)
-	}
+	notification := eventBridgeNotification(message)
+	notification.Title = title
+	notification.Severity = mapSeverity(desiredStatus)
+	notification.State = lastStatus
+	notification.Resource = taskArn
+	notification.Fields = fields
 
-	return string(resp)
+	return &notification
 }
 
-func alarm(message *gabs.Container) string {
+func alarm(message *gabs.Container) *Notification {
 	NewStateValue, _ := message.Path("NewStateValue").Data().(string)
 	NewStateReason, _ := message.Path("NewStateReason").Data().(string)
 	AlarmName, _ := message.Path("AlarmName").Data().(string)
 	Region, _ := message.Path("Region").Data().(string)
+	AWSAccountID, _ := message.Path("AWSAccountId").Data().(string)
+	StateChangeTime, _ := message.Path("StateChangeTime").Data().(string)
 
-	slackAttachmentFields := []slackAttachmentField{
-		{
-			Title: "Alarm",
-			Value: AlarmName,
-			Short: true,
-		},
-		{
-			Title: "Status",
-			Value: NewStateValue,
-			Short: true,
-		},
-		{
-			Title: "Reason",
-			Value: NewStateReason,
-			Short: false,
-		},
+	notification := alertNotification(AlarmName, NewStateValue, NewStateReason, Region)
+	notification.Source = "aws.cloudwatch"
+	notification.Account = AWSAccountID
+	notification.DedupKey = AlarmName + "/" + StateChangeTime
+
+	return &notification
+}
+
+// RenderAttachment renders a notification as a Slack message attachment
+func RenderAttachment(notification Notification) (string, error) {
+	slackAttachmentFields := make([]slackAttachmentField, 0, len(notification.Fields)+len(notification.Links))
+	for _, field := range notification.Fields {
+		slackAttachmentFields = append(slackAttachmentFields, slackAttachmentField{
+			Title: field.Title,
+			Value: field.Value,
+			Short: field.Short,
+		})
 	}
 
-	pretext := fmt.Sprintf("%s: %s in %s", NewStateValue, AlarmName, Region)
+	for _, link := range notification.Links {
+		slackAttachmentFields = append(slackAttachmentFields, slackAttachmentField{
+			Title: link.Title,
+			Value: fmt.Sprintf("<%s|%s>", link.URL, link.Title),
+			Short: true,
+		})
+	}
 
 	username := os.Getenv("USERNAME")
 	if username == "" {
@@ -159,8 +152,8 @@ func alarm(message *gabs.Container) string {
 	}
 
 	slackMessageAttachments := MessageAttachments{
-		Color:    mapColor(NewStateValue),
-		Pretext:  pretext,
+		Color:    mapColor(notification.Severity),
+		Pretext:  notification.Title,
 		Username: username,
 		Icon:     icon,
 		Fields:   slackAttachmentFields,
@@ -168,27 +161,130 @@ func alarm(message *gabs.Container) string {
 
 	resp, err := json.Marshal(slackMessageAttachments)
 	if err != nil {
-		log.Fatal("Error building Slack attachments", err)
+		return "", err
 	}
 
-	return string(resp)
+	return string(resp), nil
 }
 
-// CreateSlackMessagAttachment is a function to create slack message
-func CreateSlackMessageAttachment(snsEvent events.SNSEvent) string {
+// eventBridgeParsers maps EventBridge detail-types to the parser handling them
+var eventBridgeParsers = map[string]func(*gabs.Container) *Notification{
+	"ECS Task State Change":                               ecsTaskStateChange,
+	"CodePipeline Pipeline Execution State Change":        codePipelineStateChange,
+	"CodePipeline Stage Execution State Change":           codePipelineStateChange,
+	"CodePipeline Action Execution State Change":          codePipelineStateChange,
+	"CodeBuild Build State Change":                        codeBuildStateChange,
+	"CodeBuild Build Phase Change":                        codeBuildStateChange,
+	"CodeDeploy Deployment State-change Notification":     codeDeployStateChange,
+	"CodeDeploy Instance State-change Notification":       codeDeployStateChange,
+	"GuardDuty Finding":                                   guardDutyFinding,
+	"Security Hub Findings - Imported":                    securityHubFindings,
+	"AWS Health Event":                                    healthEvent,
+	"RDS DB Instance Event":                               rdsEvent,
+	"RDS DB Cluster Event":                                rdsEvent,
+	"EC2 Instance State-change Notification":              ec2InstanceStateChange,
+	"EC2 Spot Instance Interruption Warning":              ec2SpotInterruption,
+	"EC2 Instance Rebalance Recommendation":               ec2RebalanceRecommendation,
+	"Backup Job State Change":                             backupJobStateChange,
+	"Restore Job State Change":                            backupJobStateChange,
+	"Copy Job State Change":                               backupJobStateChange,
+	"Batch Job State Change":                              batchJobStateChange,
+	"Step Functions Execution Status Change":              stepFunctionsExecutionStatusChange,
+	"ACM Certificate Approaching Expiration":              acmCertificateApproachingExpiration,
+	"Config Rules Compliance Change":                      configRulesComplianceChange,
+	"AWS Console Sign In via CloudTrail":                  consoleSignIn,
+	"AWS API Call via CloudTrail":                         cloudTrailAPICall,
+	"ECR Image Scan":                                      ecrImageScan,
+	"ECR Image Action":                                    ecrImageAction,
+	"EC2 Automation Execution Status-change Notification": ssmAutomationExecution,
+	"EC2 Automation Step Status-change Notification":      ssmAutomationExecution,
+	"EC2 Command Status-change Notification":              ssmCommandStatusChange,
+	"EC2 State Manager Association State Change":          ssmAssociationStateChange,
+	"ECS Container Instance State Change":                 ecsContainerInstanceStateChange,
+}
+
+// CreateNotification parses the first record of an SNS event into a
+// notification. nil is returned when there is nothing to notify about.
+func CreateNotification(snsEvent events.SNSEvent) *Notification {
 	log.Println("snsEvent", snsEvent)
 	records := snsEvent.Records
 	snsRecord := records[0].SNS
 
-	message, _ := gabs.ParseJSON([]byte(snsRecord.Message))
+	message, err := gabs.ParseJSON([]byte(snsRecord.Message))
+	if err != nil {
+		if values, ok := parseCloudFormation(snsRecord.Message); ok {
+			return cloudFormation(values)
+		}
+
+		if isBudgetText(snsRecord.Message) {
+			return budgetText(snsRecord.Message)
+		}
+
+		if isBackupVaultText(snsRecord.Message) {
+			return backupVaultText(snsRecord.Message)
+		}
+
+		log.Println("Error parsing SNS message", err)
+		return nil
+	}
 
-	if message.Exists("detail-type") && message.Path("detail-type").Data().(string) == "ECS Task State Change" {
-		return ecsTaskStateChange(message)
+	if detailType, ok := message.Path("detail-type").Data().(string); ok {
+		if parse, ok := eventBridgeParsers[detailType]; ok {
+			return parse(message)
+		}
 	}
 
 	if message.Exists("AlarmName") {
 		return alarm(message)
 	}
 
-	return ""
+	if isCodeDeployTrigger(message) {
+		return codeDeployTrigger(message)
+	}
+
+	if isRdsSubscriptionEvent(message) {
+		return rdsSubscriptionEvent(message)
+	}
+
+	if isBudgetJSON(message) {
+		return budgetJSON(message)
+	}
+
+	if isCostAnomaly(message) {
+		return costAnomaly(message)
+	}
+
+	if isGrafana(message) {
+		return grafana(message)
+	}
+
+	if isAlertmanager(message) {
+		return alertmanager(message)
+	}
+
+	if isLambdaDestination(message) {
+		return lambdaDestination(message)
+	}
+
+	if isSesNotification(message) {
+		return sesNotification(message)
+	}
+
+	return nil
+}
+
+// CreateSlackMessagAttachment is a function to create slack message
+func CreateSlackMessageAttachment(snsEvent events.SNSEvent) string {
+	notification := CreateNotification(snsEvent)
+	if notification == nil {
+		return ""
+	}
+
+	resp, err := RenderAttachment(*notification)
+	if err != nil {
+		log.Println("Error building Slack attachments", err)
+		return ""
+	}
+
+	return resp
 }
@@ -517,3 +517,27 @@ func TestCreateSlackMessageAttachmentForRunningEcsTaskEvent(t *testing.T) {
 	assert.Equal(t, ":loudspeaker:", msg.Icon)
 	assert.Equal(t, attachemntsFields, msg.Fields)
 }
+
+func testSNSEvent(message string) events.SNSEvent {
+	return events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{
+				EventVersion:         "1.0",
+				EventSubscriptionArn: "arn:aws:sns:EXAMPLE",
+				EventSource:          "aws:sns",
+				SNS: events.SNSEntity{
+					SignatureVersion: "1",
+					Timestamp:        time.Now(),
+					Signature:        "EXAMPLE",
+					SigningCertURL:   "EXAMPLE",
+					MessageID:        "95df01b4-ee98-5cb9-9903-4c221d41eb5e",
+					Message:          message,
+					Type:             "Notification",
+					UnsubscribeURL:   "EXAMPLE",
+					TopicArn:         "arn:aws:sns:eu-west-1:000000000000:cloudwatch-alarms",
+					Subject:          "example subject",
+				},
+			},
+		},
+	}
+}
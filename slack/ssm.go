@@ -0,0 +1,187 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+func ssmSeverity(status string) Severity {
+	switch strings.ToLower(status) {
+	case "failed", "timedout":
+		return SeverityCritical
+	case "cancelled", "cancelling":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+func ssmConsoleURL(region, path string) string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/systems-manager/%s?region=%s", region, path, region)
+}
+
+// ssmResourceTargets lists the EC2 instances in the resources of an SSM event
+func ssmResourceTargets(message *gabs.Container) string {
+	resources, _ := message.Path("resources").Children()
+
+	var targets []string
+	for _, resource := range resources {
+		arn, _ := resource.Data().(string)
+		if !strings.Contains(arn, ":instance/") {
+			continue
+		}
+		targets = append(targets, instanceLabel(arn[strings.LastIndex(arn, "/")+1:]))
+	}
+
+	return strings.Join(targets, ", ")
+}
+
+// ssmAssociationTargets renders the JSON encoded association targets as
+// `InstanceIds=i-1,i-2`
+func ssmAssociationTargets(detail *gabs.Container) string {
+	parsed, err := parseEmbeddedJSON(detail, "targets")
+	if err != nil {
+		return ""
+	}
+
+	children, _ := parsed.Children()
+
+	var targets []string
+	for _, target := range children {
+		key, _ := target.Path("key").Data().(string)
+
+		var values []string
+		items, _ := target.Path("values").Children()
+		for _, item := range items {
+			if value, ok := item.Data().(string); ok {
+				values = append(values, value)
+			}
+		}
+
+		targets = append(targets, key+"="+strings.Join(values, ","))
+	}
+
+	return strings.Join(targets, "; ")
+}
+
+func ssmFields(document, idTitle, id, status, targets string) []Field {
+	fields := []Field{
+		{
+			Title: "Document",
+			Value: document,
+			Short: true,
+		},
+		{
+			Title: idTitle,
+			Value: id,
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+	}
+
+	if targets != "" {
+		fields = append(fields, Field{
+			Title: "Targets",
+			Value: targets,
+			Short: false,
+		})
+	}
+
+	return fields
+}
+
+// ssmAutomationExecution handles both automation execution and step status
+// changes, the latter naming the step that failed
+func ssmAutomationExecution(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	executionID, _ := detail.Path("ExecutionId").Data().(string)
+	document, _ := detail.Path("Definition").Data().(string)
+	status, _ := detail.Path("Status").Data().(string)
+	step, _ := detail.Path("StepName").Data().(string)
+	action, _ := detail.Path("Action").Data().(string)
+
+	fields := ssmFields(document, "Execution", executionID, status, ssmResourceTargets(message))
+	if step != "" {
+		label := step
+		if action != "" {
+			label += " (" + action + ")"
+		}
+		fields = append(fields, Field{
+			Title: "Step",
+			Value: label,
+			Short: false,
+		})
+	}
+
+	notification := eventBridgeNotification(message)
+
+	notification.Title = fmt.Sprintf("Automation %s of %s %s", executionID, document, status)
+	if step != "" {
+		notification.Title = fmt.Sprintf("Automation %s of %s step %s %s", executionID, document, step, status)
+	}
+	notification.Severity = ssmSeverity(status)
+	notification.State = status
+	notification.Resource = executionID
+	notification.Fields = fields
+	notification.Links = []Link{
+		{
+			Title: "Execution",
+			URL:   ssmConsoleURL(notification.Region, "automation/execution/"+executionID),
+		},
+	}
+
+	return &notification
+}
+
+func ssmCommandStatusChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	commandID, _ := detail.Path("command-id").Data().(string)
+	document, _ := detail.Path("document-name").Data().(string)
+	status, _ := detail.Path("status").Data().(string)
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Command %s of %s %s", commandID, document, status)
+	notification.Severity = ssmSeverity(status)
+	notification.State = status
+	notification.Resource = commandID
+	notification.Fields = ssmFields(document, "Command", commandID, status, ssmResourceTargets(message))
+	notification.Links = []Link{
+		{
+			Title: "Command",
+			URL:   ssmConsoleURL(notification.Region, "run-command/"+commandID),
+		},
+	}
+
+	return &notification
+}
+
+func ssmAssociationStateChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	associationID, _ := detail.Path("association-id").Data().(string)
+	document, _ := detail.Path("document-name").Data().(string)
+	status, _ := detail.Path("status").Data().(string)
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Association %s of %s %s", associationID, document, status)
+	notification.Severity = ssmSeverity(status)
+	notification.State = status
+	notification.Resource = associationID
+	notification.Fields = ssmFields(document, "Association", associationID, status, ssmAssociationTargets(detail))
+	notification.Links = []Link{
+		{
+			Title: "Association",
+			URL:   ssmConsoleURL(notification.Region, "state-manager/association/"+associationID+"/description"),
+		},
+	}
+
+	return &notification
+}
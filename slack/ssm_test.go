@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ssmAutomationStepFailedEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"eeca120b-a321-433e-9635-dab58f6f7a0d\",\"detail-type\":\"EC2 Automation Step Status-change Notification\",\"source\":\"aws.ssm\",\"account\":\"123456789000\",\"time\":\"2022-05-03T12:00:05Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ssm:eu-west-1:123456789000:automation-execution/333ba70b-2333-48db-b17e-a5e69c6f4d1c\",\"arn:aws:ssm:eu-west-1:123456789000:automation-definition/AWS-RestartEC2Instance:1\"],\"detail\":{\"ExecutionId\":\"333ba70b-2333-48db-b17e-a5e69c6f4d1c\",\"Definition\":\"AWS-RestartEC2Instance\",\"DefinitionVersion\":1.0,\"Status\":\"Failed\",\"EndTime\":\"2022-05-03T12:00:05Z\",\"StartTime\":\"2022-05-03T12:00:00Z\",\"Time\":2630.0,\"StepName\":\"stopInstances\",\"Action\":\"aws:changeInstanceState\"}}")
+
+var ssmCommandFailedEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"51c0891d-0e34-45b1-83d6-95db273d1602\",\"detail-type\":\"EC2 Command Status-change Notification\",\"source\":\"aws.ssm\",\"account\":\"123456789000\",\"time\":\"2022-05-03T12:10:00Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ec2:eu-west-1:123456789000:instance/i-0abcdef1234567890\",\"arn:aws:ec2:eu-west-1:123456789000:instance/i-0123456789abcdef0\"],\"detail\":{\"command-id\":\"e8d3c0e4-71f7-4491-898f-c9b35bee5f3b\",\"document-name\":\"AWS-RunShellScript\",\"expire-after\":\"2022-05-03T14:10:00Z\",\"parameters\":{\"commands\":[\"yum update -y\"]},\"requested-date-time\":\"2022-05-03T12:09:50Z\",\"status\":\"Failed\"}}")
+
+var ssmAssociationEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"6a7e8feb-b491-4cf7-a9f1-bf3703467718\",\"detail-type\":\"EC2 State Manager Association State Change\",\"source\":\"aws.ssm\",\"account\":\"123456789000\",\"time\":\"2022-05-03T12:20:00Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:ssm:eu-west-1::document/AWS-UpdateSSMAgent\"],\"detail\":{\"association-id\":\"6e37940a-23ba-4ab0-9b8e-6848e3f7b2a1\",\"document-name\":\"AWS-UpdateSSMAgent\",\"association-version\":\"1\",\"document-version\":\"$DEFAULT\",\"targets\":\"[{\\\"key\\\":\\\"InstanceIds\\\",\\\"values\\\":[\\\"i-0abcdef1234567890\\\",\\\"i-0123456789abcdef0\\\"]}]\",\"status\":\"Success\",\"association-status-aggregated-count\":\"{\\\"Success\\\":2}\"}}")
+
+func TestCreateSlackMessageAttachmentForSsmAutomationStepFailedEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ssmAutomationStepFailedEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "Document",
+			Value: "AWS-RestartEC2Instance",
+			Short: true,
+		},
+		{
+			Title: "Execution",
+			Value: "333ba70b-2333-48db-b17e-a5e69c6f4d1c",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "Failed",
+			Short: true,
+		},
+		{
+			Title: "Step",
+			Value: "stopInstances (aws:changeInstanceState)",
+			Short: false,
+		},
+		{
+			Title: "Execution",
+			Value: "<https://eu-west-1.console.aws.amazon.com/systems-manager/automation/execution/333ba70b-2333-48db-b17e-a5e69c6f4d1c?region=eu-west-1|Execution>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Automation 333ba70b-2333-48db-b17e-a5e69c6f4d1c of AWS-RestartEC2Instance step stopInstances Failed", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
+
+func TestCreateSlackMessageAttachmentForSsmCommandFailedEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ssmCommandFailedEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Command e8d3c0e4-71f7-4491-898f-c9b35bee5f3b of AWS-RunShellScript Failed", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Targets", Value: "i-0abcdef1234567890, i-0123456789abcdef0", Short: false}, msg.Fields[3])
+	assert.Equal(t, "<https://eu-west-1.console.aws.amazon.com/systems-manager/run-command/e8d3c0e4-71f7-4491-898f-c9b35bee5f3b?region=eu-west-1|Command>", msg.Fields[4].Value)
+}
+
+func TestCreateSlackMessageAttachmentForSsmAssociationEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(ssmAssociationEvent)
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "good", msg.Color)
+	assert.Equal(t, "Association 6e37940a-23ba-4ab0-9b8e-6848e3f7b2a1 of AWS-UpdateSSMAgent Success", msg.Pretext)
+	assert.Equal(t, slackAttachmentField{Title: "Targets", Value: "InstanceIds=i-0abcdef1234567890,i-0123456789abcdef0", Short: false}, msg.Fields[3])
+}
@@ -0,0 +1,129 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+)
+
+// maxStepFunctionsSnippet caps the length of input, output and cause snippets
+const maxStepFunctionsSnippet = 500
+
+func stepFunctionsSeverity(status string) Severity {
+	switch status {
+	case "FAILED", "TIMED_OUT":
+		return SeverityCritical
+	case "ABORTED":
+		return SeverityWarning
+	default:
+		return SeverityOK
+	}
+}
+
+// epochMillis converts the millisecond timestamps of Step Functions events
+func epochMillis(value interface{}) (time.Time, bool) {
+	millis, ok := value.(float64)
+	if !ok || millis == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, int64(millis)*int64(time.Millisecond)), true
+}
+
+func stepFunctionsExecutionStatusChange(message *gabs.Container) *Notification {
+	detail := message.Path("detail")
+
+	executionArn, _ := detail.Path("executionArn").Data().(string)
+	stateMachineArn, _ := detail.Path("stateMachineArn").Data().(string)
+	name, _ := detail.Path("name").Data().(string)
+	status, _ := detail.Path("status").Data().(string)
+	input, _ := detail.Path("input").Data().(string)
+	output, _ := detail.Path("output").Data().(string)
+	executionError, _ := detail.Path("error").Data().(string)
+	cause, _ := detail.Path("cause").Data().(string)
+
+	stateMachine := stateMachineArn[strings.LastIndex(stateMachineArn, ":")+1:]
+
+	fields := []Field{
+		{
+			Title: "State machine",
+			Value: stateMachine,
+			Short: true,
+		},
+		{
+			Title: "Execution",
+			Value: name,
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: status,
+			Short: true,
+		},
+	}
+
+	startDate, started := epochMillis(detail.Path("startDate").Data())
+	if started {
+		fields = append(fields, Field{
+			Title: "Started",
+			Value: formatTime(startDate),
+			Short: true,
+		})
+	}
+
+	if stopDate, stopped := epochMillis(detail.Path("stopDate").Data()); stopped {
+		fields = append(fields, Field{
+			Title: "Stopped",
+			Value: formatTime(stopDate),
+			Short: true,
+		})
+
+		if started {
+			fields = append(fields, Field{
+				Title: "Duration",
+				Value: stopDate.Sub(startDate).Round(time.Second).String(),
+				Short: true,
+			})
+		}
+	}
+
+	if executionError != "" {
+		fields = append(fields, Field{
+			Title: "Error",
+			Value: executionError,
+			Short: false,
+		})
+	}
+
+	for _, snippet := range []struct{ title, value string }{
+		{"Cause", cause},
+		{"Input", input},
+		{"Output", output},
+	} {
+		if snippet.value != "" {
+			fields = append(fields, Field{
+				Title: snippet.title,
+				Value: codeBlock(truncate(snippet.value, maxStepFunctionsSnippet)),
+				Short: false,
+			})
+		}
+	}
+
+	notification := eventBridgeNotification(message)
+	notification.Title = fmt.Sprintf("Execution %s of state machine %s %s", name, stateMachine, status)
+	notification.Severity = stepFunctionsSeverity(status)
+	notification.State = status
+	notification.Resource = executionArn
+	notification.Fields = fields
+	notification.Links = []Link{
+		{
+			Title: "Execution",
+			URL: fmt.Sprintf("https://%s.console.aws.amazon.com/states/home?region=%s#/executions/details/%s",
+				notification.Region, notification.Region, executionArn),
+		},
+	}
+
+	return &notification
+}
@@ -0,0 +1,75 @@
+package slack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failedStepFunctionsExecutionEvent = testSNSEvent("{\"version\":\"0\",\"id\":\"315c1398-40ff-a850-213b-158f73e60175\",\"detail-type\":\"Step Functions Execution Status Change\",\"source\":\"aws.states\",\"account\":\"123456789000\",\"time\":\"2022-05-03T02:15:30Z\",\"region\":\"eu-west-1\",\"resources\":[\"arn:aws:states:eu-west-1:123456789000:execution:nightly-etl:2022-05-03\"],\"detail\":{\"executionArn\":\"arn:aws:states:eu-west-1:123456789000:execution:nightly-etl:2022-05-03\",\"stateMachineArn\":\"arn:aws:states:eu-west-1:123456789000:stateMachine:nightly-etl\",\"name\":\"2022-05-03\",\"status\":\"FAILED\",\"startDate\":1651543200000,\"stopDate\":1651544130000,\"input\":\"{\\\"date\\\":\\\"2022-05-03\\\"}\",\"output\":null,\"inputDetails\":{\"included\":true},\"outputDetails\":null,\"error\":\"States.TaskFailed\",\"cause\":\"" + strings.Repeat("x", 600) + "\"}}")
+
+func TestCreateSlackMessageAttachmentForFailedStepFunctionsExecutionEvent(t *testing.T) {
+	slackMessageAttachments := CreateSlackMessageAttachment(failedStepFunctionsExecutionEvent)
+	attachemntsFields := []slackAttachmentField{
+		{
+			Title: "State machine",
+			Value: "nightly-etl",
+			Short: true,
+		},
+		{
+			Title: "Execution",
+			Value: "2022-05-03",
+			Short: true,
+		},
+		{
+			Title: "Status",
+			Value: "FAILED",
+			Short: true,
+		},
+		{
+			Title: "Started",
+			Value: "2022-05-03 02:00 UTC",
+			Short: true,
+		},
+		{
+			Title: "Stopped",
+			Value: "2022-05-03 02:15 UTC",
+			Short: true,
+		},
+		{
+			Title: "Duration",
+			Value: "15m30s",
+			Short: true,
+		},
+		{
+			Title: "Error",
+			Value: "States.TaskFailed",
+			Short: false,
+		},
+		{
+			Title: "Cause",
+			Value: "```" + strings.Repeat("x", 499) + "…```",
+			Short: false,
+		},
+		{
+			Title: "Input",
+			Value: "```{\"date\":\"2022-05-03\"}```",
+			Short: false,
+		},
+		{
+			Title: "Execution",
+			Value: "<https://eu-west-1.console.aws.amazon.com/states/home?region=eu-west-1#/executions/details/arn:aws:states:eu-west-1:123456789000:execution:nightly-etl:2022-05-03|Execution>",
+			Short: true,
+		},
+	}
+
+	var msg MessageAttachments
+
+	json.Unmarshal([]byte(slackMessageAttachments), &msg)
+
+	assert.Equal(t, "danger", msg.Color)
+	assert.Equal(t, "Execution 2022-05-03 of state machine nightly-etl FAILED", msg.Pretext)
+	assert.Equal(t, attachemntsFields, msg.Fields)
+}
@@ -0,0 +1,83 @@
+package sns
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NotAllowedError is returned when a message comes from a topic or account
+// outside the allowlist
+type NotAllowedError struct {
+	Field string
+	Value string
+}
+
+func (e *NotAllowedError) Error() string {
+	return fmt.Sprintf("%s %q is not allowed", e.Field, e.Value)
+}
+
+// Allowlist restricts which SNS topics and EventBridge source accounts may
+// post notifications. An empty list allows everything.
+type Allowlist struct {
+	TopicArns []string
+	Accounts  []string
+}
+
+// NewAllowlist builds an Allowlist from comma separated topic ARN patterns
+// and account IDs. Topic ARN patterns may contain `*` wildcards.
+func NewAllowlist(topicArns, accounts string) Allowlist {
+	return Allowlist{
+		TopicArns: splitList(topicArns),
+		Accounts:  splitList(accounts),
+	}
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// Check returns a *NotAllowedError if the message is not covered by the allowlist
+func (a Allowlist) Check(entity events.SNSEntity) error {
+	if len(a.TopicArns) > 0 && !matchesAny(a.TopicArns, entity.TopicArn) {
+		return &NotAllowedError{Field: "TopicArn", Value: entity.TopicArn}
+	}
+
+	if len(a.Accounts) == 0 {
+		return nil
+	}
+
+	message, err := gabs.ParseJSON([]byte(entity.Message))
+	if err != nil || !message.Exists("account") {
+		return nil
+	}
+
+	account, _ := message.Path("account").Data().(string)
+	for _, allowed := range a.Accounts {
+		if account == allowed {
+			return nil
+		}
+	}
+
+	return &NotAllowedError{Field: "account", Value: account}
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
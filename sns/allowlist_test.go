@@ -0,0 +1,37 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowlistEmptyAllowsEverything(t *testing.T) {
+	allowlist := NewAllowlist("", "")
+
+	assert.NoError(t, allowlist.Check(events.SNSEntity{
+		TopicArn: "arn:aws:sns:eu-west-1:000000000000:cloudwatch-alarms",
+		Message:  "{\"account\":\"123456789000\"}",
+	}))
+}
+
+func TestAllowlistTopicArn(t *testing.T) {
+	allowlist := NewAllowlist("arn:aws:sns:*:000000000000:cloudwatch-*, arn:aws:sns:eu-west-1:111111111111:ecs", "")
+
+	assert.NoError(t, allowlist.Check(events.SNSEntity{TopicArn: "arn:aws:sns:eu-west-1:000000000000:cloudwatch-alarms"}))
+	assert.NoError(t, allowlist.Check(events.SNSEntity{TopicArn: "arn:aws:sns:eu-west-1:111111111111:ecs"}))
+
+	err := allowlist.Check(events.SNSEntity{TopicArn: "arn:aws:sns:eu-west-1:999999999999:cloudwatch-alarms"})
+	assert.Equal(t, &NotAllowedError{Field: "TopicArn", Value: "arn:aws:sns:eu-west-1:999999999999:cloudwatch-alarms"}, err)
+}
+
+func TestAllowlistAccount(t *testing.T) {
+	allowlist := NewAllowlist("", "123456789000")
+
+	assert.NoError(t, allowlist.Check(events.SNSEntity{Message: "{\"account\":\"123456789000\"}"}))
+	assert.NoError(t, allowlist.Check(events.SNSEntity{Message: "{\"AlarmName\":\"sns-cloudwatch\"}"}))
+
+	err := allowlist.Check(events.SNSEntity{Message: "{\"account\":\"999999999999\"}"})
+	assert.Equal(t, &NotAllowedError{Field: "account", Value: "999999999999"}, err)
+}
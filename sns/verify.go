@@ -0,0 +1,176 @@
+package sns
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/parnurzeal/gorequest"
+)
+
+// snsTimestampFormat is the layout SNS uses for the Timestamp field it signs
+const snsTimestampFormat = "2006-01-02T15:04:05.000Z"
+
+var certHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com$`)
+
+// ErrInvalidSignature is returned when a message is unsigned or its signature does not match
+var ErrInvalidSignature = errors.New("invalid SNS message signature")
+
+// CertFetcher downloads the PEM encoded signing certificate found at url
+type CertFetcher func(url string) ([]byte, error)
+
+// Verifier checks SNS message signatures and caches signing certificates
+// across invocations
+type Verifier struct {
+	fetch CertFetcher
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewVerifier returns a Verifier using fetch to download certificates.
+// A nil fetch downloads certificates over HTTPS.
+func NewVerifier(fetch CertFetcher) *Verifier {
+	if fetch == nil {
+		fetch = httpCertFetcher
+	}
+
+	return &Verifier{
+		fetch: fetch,
+		certs: map[string]*x509.Certificate{},
+	}
+}
+
+func httpCertFetcher(certURL string) ([]byte, error) {
+	resp, body, errs := gorequest.New().Get(certURL).EndBytes()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching signing certificate: unexpected status %d", resp.StatusCode)
+	}
+
+	return body, nil
+}
+
+// Verify checks the signature of an SNS notification. SignatureVersion 1
+// (SHA1) and 2 (SHA256) are supported.
+func (v *Verifier) Verify(entity events.SNSEntity) error {
+	if entity.Signature == "" || entity.SigningCertURL == "" {
+		return ErrInvalidSignature
+	}
+
+	var hash crypto.Hash
+	switch entity.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("unsupported SNS signature version %q", entity.SignatureVersion)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(entity.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	cert, err := v.certificate(entity.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("SNS signing certificate does not hold an RSA key")
+	}
+
+	var digest []byte
+	payload := []byte(stringToSign(entity))
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum(payload)
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256(payload)
+		digest = sum[:]
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (v *Verifier) certificate(certURL string) (*x509.Certificate, error) {
+	if err := validateCertURL(certURL); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if cert, ok := v.certs[certURL]; ok {
+		return cert, nil
+	}
+
+	data, err := v.fetch(certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("SNS signing certificate is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	v.certs[certURL] = cert
+
+	return cert, nil
+}
+
+func validateCertURL(certURL string) error {
+	u, err := url.Parse(certURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "https" || !certHostPattern.MatchString(u.Host) || !strings.HasSuffix(u.Path, ".pem") {
+		return fmt.Errorf("untrusted SNS signing certificate URL %q", certURL)
+	}
+
+	return nil
+}
+
+// stringToSign builds the canonical string SNS signs for a Notification
+func stringToSign(entity events.SNSEntity) string {
+	var b strings.Builder
+
+	b.WriteString("Message\n" + entity.Message + "\n")
+	b.WriteString("MessageId\n" + entity.MessageID + "\n")
+	if entity.Subject != "" {
+		b.WriteString("Subject\n" + entity.Subject + "\n")
+	}
+	b.WriteString("Timestamp\n" + entity.Timestamp.UTC().Format(snsTimestampFormat) + "\n")
+	b.WriteString("TopicArn\n" + entity.TopicArn + "\n")
+	b.WriteString("Type\n" + entity.Type + "\n")
+
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package sns
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCertURL = "https://sns.eu-west-1.amazonaws.com/SimpleNotificationService-0000000000000000000000.pem"
+
+func newTestSigner(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, entity events.SNSEntity) string {
+	var hash crypto.Hash
+	var digest []byte
+	payload := []byte(stringToSign(entity))
+	if entity.SignatureVersion == "1" {
+		hash = crypto.SHA1
+		sum := sha1.Sum(payload)
+		digest = sum[:]
+	} else {
+		hash = crypto.SHA256
+		sum := sha256.Sum256(payload)
+		digest = sum[:]
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, hash, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func testEntity(signatureVersion string) events.SNSEntity {
+	return events.SNSEntity{
+		SignatureVersion: signatureVersion,
+		Timestamp:        time.Date(2022, 5, 3, 7, 29, 20, 980000000, time.UTC),
+		SigningCertURL:   testCertURL,
+		MessageID:        "95df01b4-ee98-5cb9-9903-4c221d41eb5e",
+		Message:          "{\"AlarmName\":\"sns-cloudwatch\"}",
+		Type:             "Notification",
+		TopicArn:         "arn:aws:sns:eu-west-1:000000000000:cloudwatch-alarms",
+		Subject:          "example subject",
+	}
+}
+
+func TestVerifySignatureVersions(t *testing.T) {
+	key, certPEM := newTestSigner(t)
+	fetches := 0
+	verifier := NewVerifier(func(url string) ([]byte, error) {
+		fetches++
+		return certPEM, nil
+	})
+
+	for _, version := range []string{"1", "2"} {
+		entity := testEntity(version)
+		entity.Signature = sign(t, key, entity)
+
+		assert.NoError(t, verifier.Verify(entity), "SignatureVersion %s", version)
+	}
+
+	assert.Equal(t, 1, fetches, "signing certificate should be cached")
+}
+
+func TestVerifyRejectsForgedMessage(t *testing.T) {
+	key, certPEM := newTestSigner(t)
+	verifier := NewVerifier(func(url string) ([]byte, error) {
+		return certPEM, nil
+	})
+
+	entity := testEntity("2")
+	entity.Signature = sign(t, key, entity)
+	entity.Message = "{\"AlarmName\":\"forged\"}"
+
+	assert.Equal(t, ErrInvalidSignature, verifier.Verify(entity))
+}
+
+func TestVerifyRejectsUnsignedMessage(t *testing.T) {
+	verifier := NewVerifier(func(url string) ([]byte, error) {
+		t.Fatal("certificate should not be fetched for unsigned messages")
+		return nil, nil
+	})
+
+	assert.Equal(t, ErrInvalidSignature, verifier.Verify(testEntity("1")))
+}
+
+func TestVerifyRejectsUntrustedCertURL(t *testing.T) {
+	key, certPEM := newTestSigner(t)
+	verifier := NewVerifier(func(url string) ([]byte, error) {
+		return certPEM, nil
+	})
+
+	for _, certURL := range []string{
+		"http://sns.eu-west-1.amazonaws.com/cert.pem",
+		"https://sns.eu-west-1.amazonaws.com.evil.com/cert.pem",
+		"https://evil.com/sns.eu-west-1.amazonaws.com/cert.pem",
+		"https://sns.eu-west-1.amazonaws.com/cert.txt",
+	} {
+		entity := testEntity("1")
+		entity.SigningCertURL = certURL
+		entity.Signature = sign(t, key, entity)
+
+		assert.Error(t, verifier.Verify(entity), certURL)
+	}
+}